@@ -1,9 +1,15 @@
 package config
 
 import (
+	"context"
+	"encoding"
+	"errors"
 	"fmt"
-	"os"
+	"log"
+	"maps"
 	"reflect"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -12,16 +18,76 @@ type ValueParser interface {
 	Parse(value string, field reflect.Value) error
 }
 
+// TagParser is implemented by parsers whose behaviour depends on the field's struct tags
+type TagParser interface {
+	ParseWithTags(value string, field reflect.Value, tags reflect.StructTag) error
+}
+
 // Validator is responsible for validating field values
 type Validator interface {
 	Validate(field reflect.Value, tags reflect.StructTag) error
 }
 
-// EnvLoader loads values from environment variables
+// ContextualValidator validates a field with access to the struct containing it.
+// Contextual validators run once every field of that struct has been loaded.
+type ContextualValidator interface {
+	ValidateWithParent(parent reflect.Value, field reflect.Value, tags reflect.StructTag) error
+}
+
+// AfterLoader is implemented by config structs that need to normalize or derive
+// values once loading and validation have succeeded. Nested structs are called
+// before the struct that contains them.
+type AfterLoader interface {
+	AfterLoad() error
+}
+
+// Initializer is implemented by config structs that need setup before loading,
+// such as allocating a map or setting fallback values. Init is called before
+// the struct's fields are populated, so values it sets are kept unless a
+// variable overrides them. Defaults only fill fields Init left at their zero
+// value; AfterLoad runs once loading is done.
+type Initializer interface {
+	Init()
+}
+
+// Defaulter is implemented by config structs whose defaults must be computed,
+// such as the machine's hostname. Defaults returns values keyed by full env name
+// (including any prefix); they take precedence over default tags but not over
+// WithDefaults. It is called once at the start of each load.
+type Defaulter interface {
+	Defaults() map[string]string
+}
+
+// EnvLoader loads values from environment variables. A loader is not modified
+// after construction; per-load state lives in a loadState, so one loader
+// (including the package-level default) is safe for concurrent use.
 type EnvLoader struct {
-	parsers    map[reflect.Kind]ValueParser
-	validators []Validator
-	prefix     string
+	parsers           map[reflect.Kind]ValueParser
+	typeParsers       map[reflect.Type]ValueParser
+	factories         map[reflect.Type]InterfaceFactory
+	validators        []Validator
+	contextValidators []ContextualValidator
+	prefix            string
+	fallbackPrefixes  []string
+	prefixSep         string
+	aggregate         bool
+	autoNames         bool
+	keyFunc           func(fieldName, tag string) string
+	tagNames          []string
+	foldCase          bool
+	expand            bool
+	strictExpand      bool
+	trimSpace         bool
+	defaults          map[string]string
+	aliases           map[string][]string
+	strict            bool
+	requiredByDefault bool
+	rejectUnknown     bool
+	rejectDuplicates  bool
+	skipUnsupported   bool
+	skipUnknownKV     bool
+	logger            func(string)
+	sources           []Source
 }
 
 // Option represents a configuration option for EnvLoader
@@ -34,17 +100,207 @@ func WithParser(kind reflect.Kind, parser ValueParser) Option {
 	}
 }
 
-// WithValidator adds a custom validator
+// WithTypeParser adds a custom parser for a specific type. Type parsers take
+// precedence over kind parsers, so named types sharing a kind can be parsed
+// differently, and a struct type with a parser is parsed as a single value.
+func WithTypeParser(t reflect.Type, parser ValueParser) Option {
+	return func(l *EnvLoader) {
+		l.typeParsers[t] = parser
+	}
+}
+
+// WithValidator adds a custom validator that runs for every field
 func WithValidator(validator Validator) Option {
 	return func(l *EnvLoader) {
 		l.validators = append(l.validators, validator)
 	}
 }
 
-// WithPrefix adds a prefix to all environment variable names
+// WithValidatorForKind adds a custom validator that only runs for fields of the
+// given kind. Pointer fields match the kind they point to, so a reflect.String
+// validator also sees *string fields.
+func WithValidatorForKind(kind reflect.Kind, validator Validator) Option {
+	return func(l *EnvLoader) {
+		l.validators = append(l.validators, &kindValidator{kind: kind, validator: validator})
+	}
+}
+
+// kindValidator runs a validator only for fields of one kind
+type kindValidator struct {
+	kind      reflect.Kind
+	validator Validator
+}
+
+// Validate delegates to the wrapped validator when the field's kind matches
+func (v *kindValidator) Validate(field reflect.Value, tags reflect.StructTag) error {
+	t := field.Type()
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != v.kind {
+		return nil
+	}
+	return v.validator.Validate(field, tags)
+}
+
+// WithContextualValidator adds a custom validator that can inspect sibling fields
+func WithContextualValidator(validator ContextualValidator) Option {
+	return func(l *EnvLoader) {
+		l.contextValidators = append(l.contextValidators, validator)
+	}
+}
+
+// WithPrefix adds a prefix to all environment variable names. It replaces any
+// prefixes set by an earlier WithPrefix or WithPrefixes option.
 func WithPrefix(prefix string) Option {
 	return func(l *EnvLoader) {
 		l.prefix = prefix
+		l.fallbackPrefixes = nil
+	}
+}
+
+// WithPrefixSeparator joins the loader prefix, envPrefix tags and env names with
+// exactly one sep, adding a missing separator and dropping a doubled one, so
+// WithPrefix("APP") with envPrefix:"DB" reads APP_DB_HOST for sep "_". Without
+// it prefixes are concatenated as written.
+func WithPrefixSeparator(sep string) Option {
+	return func(l *EnvLoader) {
+		l.prefixSep = sep
+	}
+}
+
+// WithPrefixes sets an ordered list of prefixes: a value is read under the first
+// prefix that has it, e.g. PRIMARY_PORT before PORT for WithPrefixes("PRIMARY_", "").
+// The first prefix is the loader's prefix as set by WithPrefix, so env names in
+// errors, usage output, and WithDefaults keys use it. Like WithPrefix, it
+// replaces the prefixes set by earlier options.
+func WithPrefixes(prefixes ...string) Option {
+	return func(l *EnvLoader) {
+		if len(prefixes) == 0 {
+			return
+		}
+		l.prefix = prefixes[0]
+		l.fallbackPrefixes = prefixes[1:]
+	}
+}
+
+// WithErrorAggregation makes the loader process every field and report all errors at once
+func WithErrorAggregation() Option {
+	return func(l *EnvLoader) {
+		l.aggregate = true
+	}
+}
+
+// WithKeyFunc computes the env name of every env-backed field from its Go name
+// and raw env tag, replacing the prefix and tag logic. A field whose key func
+// returns "" is skipped. Indexed struct slices cannot be loaded with a key
+// func, since the key does not depend on the element index.
+func WithKeyFunc(fn func(fieldName, tag string) string) Option {
+	return func(l *EnvLoader) {
+		l.keyFunc = fn
+	}
+}
+
+// WithAutoEnvNames derives env names from field names (DatabaseURL -> DATABASE_URL) for fields without an env tag
+func WithAutoEnvNames() Option {
+	return func(l *EnvLoader) {
+		l.autoNames = true
+	}
+}
+
+// WithTagName reads the env name from another struct tag, such as json, for fields
+// without an env tag. The tag's name is converted to UPPER_SNAKE_CASE, so
+// json:"databaseUrl" and json:"database_url" both map to DATABASE_URL. Tag names
+// are tried in the order they were added.
+func WithTagName(name string) Option {
+	return func(l *EnvLoader) {
+		l.tagNames = append(l.tagNames, name)
+	}
+}
+
+// WithCaseInsensitive matches environment variable names case-insensitively when
+// no variable with the exact name exists. Exact matches always take precedence.
+func WithCaseInsensitive() Option {
+	return func(l *EnvLoader) {
+		l.foldCase = true
+	}
+}
+
+// WithTrimSpace trims leading and trailing whitespace from every looked-up value
+// before parsing. Fields tagged trim:"false" keep their value unchanged.
+func WithTrimSpace() Option {
+	return func(l *EnvLoader) {
+		l.trimSpace = true
+	}
+}
+
+// WithDefaults supplies default values keyed by full env name (including any prefix).
+// They are used when a variable is unset and take precedence over default tags.
+func WithDefaults(defaults map[string]string) Option {
+	return func(l *EnvLoader) {
+		if l.defaults == nil {
+			l.defaults = make(map[string]string, len(defaults))
+		}
+		for key, value := range defaults {
+			l.defaults[key] = value
+		}
+	}
+}
+
+// WithAliases maps legacy env names to their canonical names, both in full
+// (including any prefix), e.g. {"PG_URL": "DATABASE_URL"}. An alias is read only
+// when the canonical variable is unset; several aliases of one name are tried
+// in sorted order.
+func WithAliases(aliases map[string]string) Option {
+	return func(l *EnvLoader) {
+		if l.aliases == nil {
+			l.aliases = make(map[string][]string, len(aliases))
+		}
+		for alias, canonical := range aliases {
+			l.aliases[canonical] = append(l.aliases[canonical], alias)
+			sort.Strings(l.aliases[canonical])
+		}
+	}
+}
+
+// WithStrictMode fails loading when a field resolves to an empty value, has no
+// default and was not set beforehand, even without required:"true". Pointer
+// fields and fields tagged required:"false" stay optional.
+func WithStrictMode() Option {
+	return func(l *EnvLoader) {
+		l.strict = true
+	}
+}
+
+// WithRequiredByDefault treats a field without a required tag as required:"true",
+// unless it has a default. While loading, a field fails only when its variable
+// resolves to an empty value, so explicit values such as false or 0 pass. Validate
+// has no raw values and reports zero values instead. Pointer fields are required
+// too; tag them required:"false" to keep them optional.
+func WithRequiredByDefault() Option {
+	return func(l *EnvLoader) {
+		l.requiredByDefault = true
+	}
+}
+
+// WithSkipUnsupported leaves optional fields whose type the loader cannot parse
+// at their zero value, logging a warning instead of failing the load. Required
+// fields, by tag or strict mode, still fail.
+func WithSkipUnsupported() Option {
+	return func(l *EnvLoader) {
+		l.skipUnsupported = true
+	}
+}
+
+// WithLogger sets the function that receives loader warnings, such as the use of
+// a deprecated variable name. By default warnings go to the standard logger;
+// a nil logger discards them.
+func WithLogger(logger func(string)) Option {
+	return func(l *EnvLoader) {
+		if logger == nil {
+			logger = func(string) {}
+		}
+		l.logger = logger
 	}
 }
 
@@ -58,17 +314,24 @@ func LoadConfig(cfg interface{}) error {
 // NewEnvLoader creates a new EnvLoader with default parsers and validators
 func NewEnvLoader(opts ...Option) *EnvLoader {
 	l := &EnvLoader{
-		parsers: map[reflect.Kind]ValueParser{
-			reflect.String:  &StringParser{},
-			reflect.Int64:   &Int64Parser{},
-			reflect.Int:     &IntParser{},
-			reflect.Slice:   &SliceParser{},
-			reflect.Bool:    &BoolParser{},
-			reflect.Float64: &Float64Parser{},
-		},
+		parsers:     maps.Clone(defaultParsers),
+		typeParsers: map[reflect.Type]ValueParser{},
+		factories:   map[reflect.Type]InterfaceFactory{},
+		logger:      func(msg string) { log.Print(msg) },
 		validators: []Validator{
 			&RequiredValidator{},
+			&NotBlankValidator{},
 			&RangeValidator{},
+			&StepValidator{},
+			&PatternValidator{},
+			&OneOfValidator{},
+			&LengthValidator{},
+			&URLValidator{},
+			&FormatValidator{},
+			&TimeRangeValidator{},
+		},
+		contextValidators: []ContextualValidator{
+			&RequiredIfValidator{},
 		},
 	}
 
@@ -77,51 +340,487 @@ func NewEnvLoader(opts ...Option) *EnvLoader {
 		opt(l)
 	}
 
+	// Normalize prefixes once all options are known, whatever their order
+	l.prefix = l.prefixSegment(l.prefix)
+	fallbacks := make([]string, len(l.fallbackPrefixes))
+	for i, prefix := range l.fallbackPrefixes {
+		fallbacks[i] = l.prefixSegment(prefix)
+	}
+	l.fallbackPrefixes = fallbacks
+
 	return l
 }
 
-// LoadConfig loads configuration from environment variables
+// loadState holds the per-call state of a single load so the loader itself stays immutable
+type loadState struct {
+	ctx     context.Context // nil means context.Background()
+	sources []Source
+	prefix  string // loader prefix plus the envPrefix tags of enclosing structs
+	err     error  // first source lookup failure; it aborts the load
+
+	consumed map[string]bool   // keys looked up during the load, tracked for WithRejectUnknown
+	defaults map[string]string // computed defaults from a Defaulter config
+	hit      Source            // source of the most recent successful lookup
+	report   *LoadReport       // collects field origins for LoadConfigWithReport
+
+	// templates holds the fields of the struct being loaded whose default
+	// references sibling fields and has not been overridden by a variable
+	templates map[string]bool
+}
+
+// lookup returns the value for key from the first source that has it, or "" when unset
+func (s *loadState) lookup(key string) string {
+	value, _ := s.find(key)
+	return value
+}
+
+// find returns the value for key from the first source that has it. Sources
+// implementing ContextSource are queried with the load's context; a failed
+// lookup is recorded in s.err and reported as not found.
+func (s *loadState) find(key string) (string, bool) {
+	if s.err != nil {
+		return "", false
+	}
+	if s.consumed != nil {
+		s.consumed[key] = true
+	}
+
+	for _, source := range s.sources {
+		cs, ok := source.(ContextSource)
+		if !ok {
+			if value, ok := source.Lookup(key); ok {
+				s.hit = source
+				return value, true
+			}
+			continue
+		}
+
+		ctx := s.ctx
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		value, ok, err := cs.LookupContext(ctx, key)
+		if err != nil {
+			s.err = fmt.Errorf("lookup %s: %w", key, err)
+			return "", false
+		}
+		if ok {
+			s.hit = source
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// LoadConfig loads configuration from the configured sources, the process environment by default
 func (l *EnvLoader) LoadConfig(cfg interface{}) error {
+	return l.LoadConfigContext(context.Background(), cfg)
+}
+
+// MustLoad loads cfg using the default loader and panics if loading fails
+func MustLoad(cfg interface{}) {
+	defaultLoader.MustLoad(cfg)
+}
+
+// MustLoad is like LoadConfig but panics with the returned error, for use in
+// variable initializers and tests. The error can be recovered and inspected
+// with errors.As.
+func (l *EnvLoader) MustLoad(cfg interface{}) {
+	if err := l.LoadConfig(cfg); err != nil {
+		panic(err)
+	}
+}
+
+// LoadConfigContext loads configuration like LoadConfig, passing ctx to sources
+// that implement ContextSource so remote lookups can be canceled or time out.
+// A failed lookup aborts the load, even with error aggregation enabled.
+func (l *EnvLoader) LoadConfigContext(ctx context.Context, cfg interface{}) error {
+	return l.load(cfg, &loadState{ctx: ctx, sources: l.callSources()})
+}
+
+// LoadFromMap loads configuration from values instead of the configured sources.
+// Prefixes and defaults are applied exactly as for environment variables.
+func (l *EnvLoader) LoadFromMap(values map[string]string, cfg interface{}) error {
+	return l.load(cfg, &loadState{sources: []Source{MapSource(values)}})
+}
+
+// load populates cfg using the sources provided by the load state
+func (l *EnvLoader) load(cfg interface{}, s *loadState) error {
 	v := reflect.ValueOf(cfg)
+	if cfg == nil || (v.Kind() == reflect.Ptr && v.IsNil()) {
+		return errors.New(ErrConfigNilPtr)
+	}
 	if v.Kind() != reflect.Ptr {
-		return fmt.Errorf("config must be a pointer")
+		return errors.New(ErrConfigNotPtr)
+	}
+	if v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%s, got %s", ErrConfigNotStruct, v.Type())
+	}
+
+	s.prefix = l.prefix
+	initialize(v.Elem())
+	if d, ok := cfg.(Defaulter); ok {
+		s.defaults = d.Defaults()
+	}
+	if err := l.checkDuplicates(v.Elem()); err != nil {
+		return err
+	}
+	if l.rejectUnknown {
+		s.consumed = make(map[string]bool)
+	}
+	if err := l.loadStruct(s, v.Elem(), ""); err != nil {
+		return err
+	}
+	if err := l.checkUnknown(s); err != nil {
+		return err
+	}
+	return afterLoad(v.Elem(), "")
+}
+
+// Validate runs the default loader's validators against cfg without reading the environment
+func Validate(cfg interface{}) error {
+	return defaultLoader.Validate(cfg)
+}
+
+// Validate runs all registered validators against the current field values of cfg
+// without reading the environment, recursing into nested structs. Every failing
+// field and group is reported in the returned error.
+func (l *EnvLoader) Validate(cfg interface{}) error {
+	v, ok := configStruct(cfg)
+	if !ok {
+		return errors.New(ErrValidateNotStruct)
 	}
 
-	return l.loadStruct(v.Elem())
+	var errs []error
+	_ = l.walkFields(v, l.prefix, "", func(f fieldRef) error {
+		if f.Placeholder {
+			return nil
+		}
+		err := l.validateField(f.Value, f.Field)
+		if err == nil && l.isRequiredByDefault(f.Field) {
+			err = (&RequiredValidator{ByDefault: true}).Validate(f.Value, f.Field.Tag)
+		}
+		if err == nil {
+			err = l.validateWithParent(f.Parent, f.Value, f.Field)
+		}
+		if err != nil {
+			errs = append(errs, fieldError(f.EnvKey, f.Path, validationKind(err), err))
+		}
+		return nil
+	})
+	errs = append(errs, l.validateStructs(v, l.prefix, "")...)
+
+	return errors.Join(errs...)
+}
+
+// validateStructs runs the checks that span the fields of a struct, such as
+// group constraints and required nested structs, on v and every struct nested in it
+func (l *EnvLoader) validateStructs(v reflect.Value, prefix, path string) []error {
+	errs := l.validateGroups(v, prefix, path)
+
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		fieldType := t.Field(i)
+		if isSkipped(fieldType) || (!fieldType.IsExported() && !fieldType.Anonymous) {
+			continue
+		}
+
+		if l.isNestedStruct(field, fieldType) || l.isNestedStructPtr(field, fieldType) {
+			nested := field
+			if field.Kind() == reflect.Ptr {
+				if field.IsNil() {
+					nested = reflect.New(field.Type().Elem())
+				}
+				nested = nested.Elem()
+			}
+			if err := l.checkRequiredStruct(nested, prefix, fieldType, joinFieldPath(path, fieldType.Name)); err != nil {
+				errs = append(errs, err)
+			}
+			errs = append(errs, l.validateStructs(nested, l.nestedPrefix(prefix, fieldType), nestedPath(path, fieldType))...)
+		} else if l.isStructSlice(field, fieldType) {
+			fieldPath := joinFieldPath(path, fieldType.Name)
+			for j := 0; j < field.Len(); j++ {
+				errs = append(errs, l.validateStructs(field.Index(j), l.indexPrefix(prefix, fieldType, j), indexPath(fieldPath, j))...)
+			}
+		}
+	}
+	return errs
 }
 
-// loadStruct processes a struct, loading environment variables into its fields
-func (l *EnvLoader) loadStruct(v reflect.Value) error {
+// loadStruct processes a struct, loading environment variables into its fields.
+// Errors are prefixed with the dotted field path; with aggregation enabled every
+// field is processed and the errors are joined.
+func (l *EnvLoader) loadStruct(s *loadState, v reflect.Value, path string) error {
 	t := v.Type()
+	var errs []error
+
+	templates := s.templates
+	s.templates = l.fieldTemplates(t, s.prefix)
+	defer func() { s.templates = templates }()
 
 	for i := 0; i < v.NumField(); i++ {
 		field := v.Field(i)
 		fieldType := t.Field(i)
+		fieldPath := joinFieldPath(path, fieldType.Name)
+		if isSkipped(fieldType) || l.isHiddenStruct(field, fieldType) {
+			continue
+		}
 
-		// Handle nested structs
-		if l.isNestedStruct(field) {
-			if err := l.loadStruct(field); err != nil {
-				return fmt.Errorf("field %s: %w", fieldType.Name, err)
+		var err error
+		if l.isJSONStruct(field, fieldType) {
+			err = l.loadJSONStruct(s, field, fieldType, path, fieldPath)
+		} else if l.isNestedStruct(field, fieldType) {
+			// Nested errors already carry the full field path
+			err = l.loadNested(s, field, fieldType, nestedPath(path, fieldType))
+			if err == nil {
+				err = l.checkRequiredStruct(field, s.prefix, fieldType, fieldPath)
+			}
+		} else if l.isNestedStructPtr(field, fieldType) {
+			if field.IsNil() {
+				if !field.CanSet() {
+					// Embedded pointer to an unexported struct type
+					continue
+				}
+				field.Set(reflect.New(field.Type().Elem()))
+			}
+			err = l.loadNested(s, field.Elem(), fieldType, nestedPath(path, fieldType))
+			if err == nil {
+				err = l.checkRequiredStruct(field.Elem(), s.prefix, fieldType, fieldPath)
 			}
+		} else if l.isStructSlice(field, fieldType) {
+			err = l.loadStructSlice(s, field, fieldType, fieldPath)
+		} else if l.isFactoryField(field) {
+			err = l.loadFactoryField(s, field, fieldType, fieldPath)
+		} else {
+			err = l.loadField(s, field, fieldType, fieldPath)
+		}
+
+		if s.err != nil {
+			return s.err
+		}
+		if err == nil {
 			continue
 		}
+		if !l.aggregate {
+			return err
+		}
+		errs = appendErrors(errs, err)
+	}
 
-		if err := l.loadField(field, fieldType); err != nil {
-			return fmt.Errorf("field %s: %w", fieldType.Name, err)
+	// Defaults referencing other fields and contextual validators need every
+	// sibling loaded first
+	for _, err := range l.resolveTemplates(s, v, path) {
+		if !l.aggregate {
+			return err
+		}
+		errs = append(errs, err)
+	}
+	for _, err := range l.validateStruct(v, s.prefix, path) {
+		if !l.aggregate {
+			return err
 		}
+		errs = append(errs, err)
 	}
 
+	return errors.Join(errs...)
+}
+
+// initialize calls Init on v when it implements Initializer
+func initialize(v reflect.Value) {
+	if v.CanAddr() {
+		v = v.Addr()
+	}
+	if !v.CanInterface() {
+		return
+	}
+	if init, ok := v.Interface().(Initializer); ok {
+		init.Init()
+	}
+}
+
+// afterLoad calls AfterLoad on v when it implements AfterLoader
+func afterLoad(v reflect.Value, path string) error {
+	if v.CanAddr() {
+		v = v.Addr()
+	}
+	if !v.CanInterface() {
+		return nil
+	}
+	hook, ok := v.Interface().(AfterLoader)
+	if !ok {
+		return nil
+	}
+
+	if err := hook.AfterLoad(); err != nil {
+		if path != "" {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		return err
+	}
 	return nil
 }
 
+// validateStruct runs the contextual validators for the env-backed fields of v
+func (l *EnvLoader) validateStruct(v reflect.Value, prefix, path string) []error {
+	t := v.Type()
+	var errs []error
+
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		fieldType := t.Field(i)
+		envKey := l.fullKey(prefix, fieldType)
+		if l.isNestedStruct(field, fieldType) || l.isNestedStructPtr(field, fieldType) || l.isStructSlice(field, fieldType) || envKey == "" {
+			continue
+		}
+
+		if err := l.validateWithParent(v, field, fieldType); err != nil {
+			errs = append(errs, fieldError(envKey, joinFieldPath(path, fieldType.Name), validationKind(err), err))
+		}
+	}
+
+	return append(errs, l.validateGroups(v, prefix, path)...)
+}
+
+// prefixSegment makes a prefix end in exactly one separator when
+// WithPrefixSeparator is set; otherwise it is returned unchanged
+func (l *EnvLoader) prefixSegment(prefix string) string {
+	if l.prefixSep == "" {
+		return prefix
+	}
+	trimmed := strings.Trim(prefix, l.prefixSep)
+	if trimmed == "" {
+		return ""
+	}
+	return trimmed + l.prefixSep
+}
+
+// nestedPrefix extends prefix with the field's envPrefix tag
+func (l *EnvLoader) nestedPrefix(prefix string, fieldType reflect.StructField) string {
+	return prefix + l.prefixSegment(fieldType.Tag.Get(EnvPrefixTag))
+}
+
+// loadNested loads a nested struct, extending the current prefix with its envPrefix tag
+func (l *EnvLoader) loadNested(s *loadState, v reflect.Value, fieldType reflect.StructField, path string) error {
+	prefix := s.prefix
+	s.prefix = l.nestedPrefix(prefix, fieldType)
+	defer func() { s.prefix = prefix }()
+
+	if !fieldType.Anonymous {
+		initialize(v)
+	}
+	if err := l.loadStruct(s, v, path); err != nil {
+		return err
+	}
+	// An embedded struct's hook is promoted to the struct embedding it
+	if fieldType.Anonymous {
+		return nil
+	}
+	return afterLoad(v, path)
+}
+
+// checkRequiredStruct fails when a nested struct tagged required:"true" has no
+// non-zero field once loaded. Defaults count as values. The error's env name is
+// the struct's prefix followed by *.
+func (l *EnvLoader) checkRequiredStruct(v reflect.Value, prefix string, fieldType reflect.StructField, path string) error {
+	if fieldType.Tag.Get(RequiredTag) != TagTrue || !v.IsZero() {
+		return nil
+	}
+	return fieldError(l.nestedPrefix(prefix, fieldType)+"*", path, KindRequired, &requiredError{msg: ErrRequiredBlock})
+}
+
+// nestedPath returns the field path for the fields of a nested struct. Embedded
+// structs add no path element because their fields are promoted.
+func nestedPath(path string, fieldType reflect.StructField) string {
+	if fieldType.Anonymous {
+		return path
+	}
+	return joinFieldPath(path, fieldType.Name)
+}
+
+// joinFieldPath appends a field name to a dotted field path
+func joinFieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// appendErrors appends err to errs, flattening joined errors
+func appendErrors(errs []error, err error) []error {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		return append(errs, joined.Unwrap()...)
+	}
+	return append(errs, err)
+}
+
 // Helper to identify special types like time.Time
 func isTimeType(t reflect.Type) bool {
 	return t == reflect.TypeOf(time.Time{})
 }
 
+// textUnmarshalerType is the reflect.Type of encoding.TextUnmarshaler
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// Helper to check if a type parses itself via encoding.TextUnmarshaler
+func isTextUnmarshaler(t reflect.Type) bool {
+	return reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
 // Helper to check if a field is a nested struct
-func (l *EnvLoader) isNestedStruct(field reflect.Value) bool {
-	return field.Kind() == reflect.Struct && !isTimeType(field.Type())
+func (l *EnvLoader) isNestedStruct(field reflect.Value, fieldType reflect.StructField) bool {
+	return !isJSONField(fieldType) && !isKVField(fieldType) && l.isNestedStructType(field.Type())
+}
+
+// isHiddenStruct reports whether the field is an unexported, non-embedded nested
+// struct without an envPrefix tag, which is skipped like other untagged
+// unexported fields
+func (l *EnvLoader) isHiddenStruct(field reflect.Value, fieldType reflect.StructField) bool {
+	if fieldType.IsExported() || fieldType.Anonymous || fieldType.Tag.Get(EnvPrefixTag) != "" {
+		return false
+	}
+	return l.isNestedStruct(field, fieldType) || l.isNestedStructPtr(field, fieldType)
+}
+
+// Helper to check if a field is a pointer to a nested struct
+func (l *EnvLoader) isNestedStructPtr(field reflect.Value, fieldType reflect.StructField) bool {
+	return !isJSONField(fieldType) && !isKVField(fieldType) && field.Kind() == reflect.Ptr && l.isNestedStructType(field.Type().Elem())
+}
+
+// isJSONStruct reports whether the field is a struct, or pointer to one, tagged format:"json"
+func (l *EnvLoader) isJSONStruct(field reflect.Value, fieldType reflect.StructField) bool {
+	t := field.Type()
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return isJSONField(fieldType) && l.isNestedStructType(t)
+}
+
+// isSkipped reports whether the field is excluded from loading with env:"-"
+func isSkipped(fieldType reflect.StructField) bool {
+	return fieldType.Tag.Get(EnvTag) == TagSkip
+}
+
+// isJSONField reports whether the field is decoded as a whole from a JSON value
+func isJSONField(fieldType reflect.StructField) bool {
+	return fieldType.Tag.Get(FormatTag) == FormatJSON
+}
+
+// Helper to check if a type is a struct whose fields should be loaded individually
+func (l *EnvLoader) isNestedStructType(t reflect.Type) bool {
+	if _, ok := l.typeParser(t); ok {
+		return false
+	}
+	return t.Kind() == reflect.Struct && !isTimeType(t) && !isTextUnmarshaler(t)
+}
+
+// typeParser returns the parser registered for t, preferring WithTypeParser over the built-in type parsers
+func (l *EnvLoader) typeParser(t reflect.Type) (ValueParser, bool) {
+	if parser, ok := l.typeParsers[t]; ok {
+		return parser, true
+	}
+	parser, ok := typeParsers[t]
+	return parser, ok
 }
 
 // getParserForType returns a parser for the specified kind
@@ -131,78 +830,364 @@ func (l *EnvLoader) getParserForType(kind reflect.Kind) (ValueParser, bool) {
 }
 
 // loadField processes a single field, loading from environment variable
-func (l *EnvLoader) loadField(field reflect.Value, fieldType reflect.StructField) error {
-	envKey := fieldType.Tag.Get("env")
+func (l *EnvLoader) loadField(s *loadState, field reflect.Value, fieldType reflect.StructField, path string) error {
+	envKey := l.fullKey(s.prefix, fieldType)
 	if envKey == "" {
 		return nil
 	}
+	if !field.CanSet() {
+		return fieldError(envKey, path, KindParse, errors.New(ErrUnexportedField))
+	}
+	l.markKnown(s, envKey, fieldType)
+	envValue, origin := l.getEnvValueWithDefault(s, envKey, fieldType)
+	if origin == OriginDefault && !field.IsZero() {
+		// A value set before loading, e.g. by Init, takes precedence over defaults
+		envValue, origin = "", OriginUnset
+	}
+	if s.templates[fieldType.Name] && origin == OriginDefault && envValue == fieldType.Tag.Get(DefaultTag) {
+		// Resolved by resolveTemplates once the sibling fields are loaded
+		return nil
+	}
+	delete(s.templates, fieldType.Name)
+
+	return l.setFieldValue(s, field, fieldType, path, envKey, envValue, origin)
+}
 
-	envValue := l.getEnvValueWithDefault(envKey, fieldType)
+// loadJSONStruct decodes a format:"json" struct field from its env value, or
+// loads it field by field like any nested struct when the value is empty
+func (l *EnvLoader) loadJSONStruct(s *loadState, field reflect.Value, fieldType reflect.StructField, path, fieldPath string) error {
+	if envKey := l.fullKey(s.prefix, fieldType); envKey != "" && field.CanSet() {
+		if envValue, origin := l.getEnvValueWithDefault(s, envKey, fieldType); envValue != "" {
+			return l.setFieldValue(s, field, fieldType, fieldPath, envKey, envValue, origin)
+		}
+	}
 
-	return l.parseAndValidateField(envValue, field, fieldType)
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			if !field.CanSet() {
+				return nil
+			}
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+		field = field.Elem()
+	}
+	return l.loadNested(s, field, fieldType, nestedPath(path, fieldType))
+}
+
+// setFieldValue expands, transforms, records, parses and validates a value read for a field
+func (l *EnvLoader) setFieldValue(s *loadState, field reflect.Value, fieldType reflect.StructField, path, envKey, envValue string, origin ValueOrigin) error {
+	envValue, err := l.expandValue(s, envValue)
+	if err == nil {
+		envValue, err = applyTransforms(envValue, fieldType)
+	}
+	if err != nil {
+		return fieldError(envKey, path, KindParse, err)
+	}
+	s.report.add(path, envKey, envValue, origin, fieldType)
+
+	return l.parseAndValidateField(envKey, path, envValue, field, fieldType)
+}
+
+// fullKey returns the env name a field is looked up under: prefix plus its env
+// name, or the key func's result when WithKeyFunc is set. It is empty for
+// fields that are not env-backed.
+func (l *EnvLoader) fullKey(prefix string, fieldType reflect.StructField) string {
+	envKey := l.envKey(fieldType)
+	if envKey == "" {
+		return ""
+	}
+	if l.keyFunc != nil {
+		return l.keyFunc(fieldType.Name, fieldType.Tag.Get(EnvTag))
+	}
+	return prefix + envKey
 }
 
-// getEnvValueWithDefault retrieves the environment value or uses default if provided
-func (l *EnvLoader) getEnvValueWithDefault(envKey string, fieldType reflect.StructField) string {
-	// Apply prefix if set
-	if l.prefix != "" {
-		envKey = l.prefix + envKey
+// envKey returns the env name for a field, deriving it from the field name when auto naming is enabled
+func (l *EnvLoader) envKey(fieldType reflect.StructField) string {
+	if isSkipped(fieldType) {
+		return ""
+	}
+	if tag := fieldType.Tag.Get(EnvTag); tag != "" {
+		key, _, _ := strings.Cut(tag, ",")
+		return strings.TrimSpace(key)
+	}
+	if !fieldType.IsExported() {
+		return ""
+	}
+	for _, tagName := range l.tagNames {
+		name, _, _ := strings.Cut(fieldType.Tag.Get(tagName), ",")
+		if name != "" && name != TagSkip {
+			return toScreamingSnake(strings.ReplaceAll(name, "-", "_"))
+		}
+	}
+	if l.autoNames {
+		return toScreamingSnake(fieldType.Name)
 	}
+	return ""
+}
 
-	// Get value from environment or use default
-	envValue := os.Getenv(envKey)
+// getEnvValueWithDefault retrieves the environment value or uses default if
+// provided, reporting where the value came from
+func (l *EnvLoader) getEnvValueWithDefault(s *loadState, envKey string, fieldType reflect.StructField) (string, ValueOrigin) {
+	// Get value from the lookup source or use default
+	envValue := s.lookup(envKey)
+	if envValue == "" {
+		envValue = alternateValue(s, fieldType)
+	}
 	if envValue == "" {
-		defaultValue := fieldType.Tag.Get("default")
-		if defaultValue != "" {
-			envValue = defaultValue
+		envValue = l.fallbackValue(s, envKey)
+	}
+	if envValue == "" {
+		envValue = l.deprecatedValue(s, envKey, fieldType)
+	}
+	if envValue == "" {
+		envValue = l.aliasValue(s, envKey)
+	}
+	if l.trimSpace && fieldType.Tag.Get(TrimTag) != TagFalse {
+		envValue = strings.TrimSpace(envValue)
+	}
+	if envValue != "" {
+		// Lookups stop at the first non-empty value, so the last hit supplied it
+		return envValue, sourceOrigin(s.hit)
+	}
+
+	if envValue = l.defaultValue(envKey, fieldType, s.defaults); envValue != "" {
+		return envValue, OriginDefault
+	}
+	return "", OriginUnset
+}
+
+// aliasValue looks up the WithAliases names of envKey in turn and returns the first non-empty value
+func (l *EnvLoader) aliasValue(s *loadState, envKey string) string {
+	for _, alias := range l.aliases[envKey] {
+		if value := s.lookup(alias); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// alternateValue looks up the candidate names after the first in an env tag
+// such as env:"DATABASE_URL,DB_URL", in order, and returns the first non-empty value
+func alternateValue(s *loadState, fieldType reflect.StructField) string {
+	for _, name := range alternateNames(fieldType) {
+		if value := s.lookup(s.prefix + name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// alternateNames returns the non-empty candidate names after the first in an env tag
+func alternateNames(fieldType reflect.StructField) []string {
+	_, rest, ok := strings.Cut(fieldType.Tag.Get(EnvTag), ",")
+	if !ok {
+		return nil
+	}
+	var names []string
+	for _, name := range strings.Split(rest, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// fallbackValue looks up envKey under each fallback prefix in turn, replacing
+// the loader's prefix, and returns the first non-empty value
+func (l *EnvLoader) fallbackValue(s *loadState, envKey string) string {
+	name := strings.TrimPrefix(envKey, l.prefix)
+	for _, prefix := range l.fallbackPrefixes {
+		if value := s.lookup(prefix + name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// deprecatedValue looks up the field's deprecated name, warning when it is set
+func (l *EnvLoader) deprecatedValue(s *loadState, envKey string, fieldType reflect.StructField) string {
+	oldName := fieldType.Tag.Get(DeprecatedTag)
+	if oldName == "" {
+		return ""
+	}
+
+	oldKey := s.prefix + oldName
+	value := s.lookup(oldKey)
+	if value != "" {
+		l.logger(fmt.Sprintf("config: %s is deprecated, use %s instead", oldKey, envKey))
+	}
+	return value
+}
+
+// defaultValue returns the default for a field, preferring WithDefaults, then
+// the computed defaults of a Defaulter config, then the default tag
+func (l *EnvLoader) defaultValue(envKey string, fieldType reflect.StructField, computed map[string]string) string {
+	if value := l.defaults[envKey]; value != "" {
+		return value
+	}
+	if value := computed[envKey]; value != "" {
+		return value
+	}
+	return fieldType.Tag.Get(DefaultTag)
+}
+
+// parseAndValidateField handles parsing and validation for a single field,
+// reporting failures as a *FieldError
+func (l *EnvLoader) parseAndValidateField(envKey, path, envValue string, field reflect.Value, fieldType reflect.StructField) error {
+	// A value set before loading, e.g. by Init, satisfies strict mode
+	if envValue == "" && l.isStrictField(field, fieldType) && field.IsZero() {
+		return fieldError(envKey, path, KindRequired, &requiredError{msg: ErrUnsetStrict})
+	}
+	if envValue == "" && l.isRequiredByDefault(fieldType) && field.IsZero() {
+		return fieldError(envKey, path, KindRequired, &requiredError{msg: ErrRequiredField})
+	}
+	if err := l.parseField(envValue, field, fieldType); err != nil {
+		if l.skipUnsupported && isUnsupported(err) && !l.isRequiredField(field, fieldType) {
+			l.logger(fmt.Sprintf("config: skipping %s (field %s): %v", envKey, path, err))
+			return nil
+		}
+		var elemErr *elementError
+		if errors.As(err, &elemErr) {
+			path, err = path+elemErr.path, elemErr.err
+		}
+		if fieldType.Tag.Get(SecretTag) == TagTrue {
+			err = redactParseError(err)
 		}
+		return fieldError(envKey, path, KindParse, err)
 	}
+	if err := l.validateField(field, fieldType); err != nil {
+		return fieldError(envKey, path, validationKind(err), err)
+	}
+	if isKVField(fieldType) && envValue != "" {
+		if name, err := l.validateKV(field); err != nil {
+			return fieldError(envKey, joinFieldPath(path, name), validationKind(err), err)
+		}
+	}
+	return nil
+}
 
-	return envValue
+// isRequiredField reports whether the field must have a value, by tag, strict mode
+// or WithRequiredByDefault
+func (l *EnvLoader) isRequiredField(field reflect.Value, fieldType reflect.StructField) bool {
+	return l.isRequiredTag(fieldType.Tag) || l.isStrictField(field, fieldType)
 }
 
-// parseAndValidateField handles parsing and validation for a single field
-func (l *EnvLoader) parseAndValidateField(envValue string, field reflect.Value, fieldType reflect.StructField) error {
-	// Special handling for time.Duration
-	if fieldType.Type == reflect.TypeOf(time.Duration(0)) {
-		return l.parseAndValidateDuration(envValue, field, fieldType)
+// isRequiredTag reports whether the tags make a field required, by tag or
+// WithRequiredByDefault
+func (l *EnvLoader) isRequiredTag(tags reflect.StructTag) bool {
+	return (&RequiredValidator{ByDefault: l.requiredByDefault}).isRequired(tags)
+}
+
+// isRequiredByDefault reports whether WithRequiredByDefault, rather than a
+// required tag, makes the field required
+func (l *EnvLoader) isRequiredByDefault(fieldType reflect.StructField) bool {
+	return l.requiredByDefault && fieldType.Tag.Get(RequiredTag) == "" &&
+		(&RequiredValidator{ByDefault: true}).isRequired(fieldType.Tag)
+}
+
+// isStrictField reports whether strict mode requires a value for the field
+func (l *EnvLoader) isStrictField(field reflect.Value, fieldType reflect.StructField) bool {
+	return l.strict && field.Kind() != reflect.Ptr && fieldType.Tag.Get(RequiredTag) != TagFalse
+}
+
+// parseField parses a raw value into the field using the parser matching its type
+func (l *EnvLoader) parseField(envValue string, field reflect.Value, fieldType reflect.StructField) error {
+	// Pointers stay nil unless a value is present, so "unset" differs from the zero value
+	if field.Kind() == reflect.Ptr {
+		return l.parsePointer(envValue, field, fieldType)
+	}
+
+	// JSON-formatted values are decoded as a whole instead of comma-splitting
+	if isJSONField(fieldType) {
+		return (&JSONParser{}).Parse(envValue, field)
+	}
+
+	// key=value lists fill the sub-fields of a struct
+	if isKVField(fieldType) && field.Kind() == reflect.Struct {
+		return l.parseKV(envValue, field)
+	}
+
+	// Special handling for concrete types such as time.Duration and net.IP
+	if parser, ok := l.typeParser(field.Type()); ok {
+		return parseValue(parser, envValue, field, fieldType.Tag)
+	}
+
+	// Types implementing encoding.TextUnmarshaler parse themselves
+	if field.CanAddr() && isTextUnmarshaler(field.Type()) {
+		return parseText(envValue, field)
 	}
 
 	// Special handling for slices
 	if field.Kind() == reflect.Slice {
-		return l.parseAndValidateSlice(envValue, field, fieldType)
+		sliceParser := &SliceParser{types: l.typeParser, tags: fieldType.Tag}
+		// Use ParseWithContext to inject the parser provider function
+		return sliceParser.ParseWithContext(envValue, field, l.getParserForType)
+	}
+
+	// Special handling for fixed-size arrays
+	if field.Kind() == reflect.Array {
+		arrayParser := &ArrayParser{types: l.typeParser, tags: fieldType.Tag}
+		return arrayParser.ParseWithContext(envValue, field, l.getParserForType)
+	}
+
+	// Special handling for maps
+	if field.Kind() == reflect.Map {
+		mapParser := &MapParser{types: l.typeParser, tags: fieldType.Tag}
+		return mapParser.ParseWithContext(envValue, field, l.getParserForType)
 	}
 
 	// Parse other types
 	parser, ok := l.parsers[field.Kind()]
 	if !ok {
-		return fmt.Errorf("unsupported type: %v", field.Kind())
+		return &unsupportedError{msg: fmt.Sprintf(ErrUnsupportedType, field.Kind())}
 	}
 
-	if err := parser.Parse(envValue, field); err != nil {
+	return parseValue(parser, envValue, field, fieldType.Tag)
+}
+
+// parseValue runs parser, passing the struct tags along when it implements TagParser
+func parseValue(parser ValueParser, value string, field reflect.Value, tags reflect.StructTag) error {
+	if tp, ok := parser.(TagParser); ok {
+		return tp.ParseWithTags(value, field, tags)
+	}
+	return parser.Parse(value, field)
+}
+
+// parsePointer allocates and parses the pointed-to value when a value is present
+func (l *EnvLoader) parsePointer(envValue string, field reflect.Value, fieldType reflect.StructField) error {
+	if envValue == "" {
+		return nil
+	}
+
+	elem := reflect.New(field.Type().Elem())
+	elemType := fieldType
+	elemType.Type = elemType.Type.Elem()
+	if err := l.parseField(envValue, elem.Elem(), elemType); err != nil {
 		return err
 	}
 
-	return l.validateField(field, fieldType)
+	field.Set(elem)
+	return nil
 }
 
-// parseAndValidateDuration parses and validates a time.Duration field
-func (l *EnvLoader) parseAndValidateDuration(envValue string, field reflect.Value, fieldType reflect.StructField) error {
-	parser := &DurationParser{}
-	if err := parser.Parse(envValue, field); err != nil {
-		return err
+// parseText unmarshals a non-empty value via encoding.TextUnmarshaler
+func parseText(envValue string, field reflect.Value) error {
+	if envValue == "" {
+		return nil
 	}
-	return l.validateField(field, fieldType)
+	u := field.Addr().Interface().(encoding.TextUnmarshaler)
+	return u.UnmarshalText([]byte(envValue))
 }
 
-// parseAndValidateSlice parses and validates a slice field
-func (l *EnvLoader) parseAndValidateSlice(envValue string, field reflect.Value, fieldType reflect.StructField) error {
-	sliceParser := &SliceParser{}
-	// Use ParseWithContext to inject the parser provider function
-	if err := sliceParser.ParseWithContext(envValue, field, l.getParserForType); err != nil {
-		return err
+// validateWithParent validates a field using all registered contextual validators
+func (l *EnvLoader) validateWithParent(parent, field reflect.Value, fieldType reflect.StructField) error {
+	for _, validator := range l.contextValidators {
+		if err := validator.ValidateWithParent(parent, field, fieldType.Tag); err != nil {
+			return err
+		}
 	}
-	return l.validateField(field, fieldType)
+	return nil
 }
 
 // validateField validates a field using all registered validators
@@ -1,8 +1,16 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/url"
 	"os"
 	"reflect"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -99,6 +107,55 @@ func TestLoadConfigEmptyValues(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestMustLoad(t *testing.T) {
+	type MustConfig struct {
+		Port int `env:"MUST_PORT" default:"8080"`
+	}
+
+	os.Unsetenv("MUST_PORT")
+	cfg := &MustConfig{}
+	assert.NotPanics(t, func() { MustLoad(cfg) })
+	assert.Equal(t, 8080, cfg.Port)
+
+	os.Setenv("MUST_PORT", "abc")
+	defer os.Unsetenv("MUST_PORT")
+
+	defer func() {
+		r := recover()
+		err, ok := r.(error)
+		assert.True(t, ok, "panic value should be an error, got %v", r)
+
+		var fieldErr *FieldError
+		assert.ErrorAs(t, err, &fieldErr)
+		assert.Equal(t, "MUST_PORT", fieldErr.EnvKey)
+	}()
+	NewEnvLoader().MustLoad(&MustConfig{})
+	t.Fatal("MustLoad did not panic")
+}
+
+func TestLoadConfigInvalidTarget(t *testing.T) {
+	n := 0
+	tests := []struct {
+		name    string
+		cfg     interface{}
+		wantErr string
+	}{
+		{"nil interface", nil, ErrConfigNilPtr},
+		{"typed nil pointer", (*TestConfig)(nil), ErrConfigNilPtr},
+		{"non-pointer", TestConfig{}, ErrConfigNotPtr},
+		{"non-struct pointee", &n, ErrConfigNotStruct + ", got *int"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotPanics(t, func() {
+				err := LoadConfig(tt.cfg)
+				assert.EqualError(t, err, tt.wantErr)
+			})
+		})
+	}
+}
+
 func TestLoadConfigPartialValues(t *testing.T) {
 	// Set partial environment variables for testing
 	os.Setenv("STRING_FIELD", "test_string")
@@ -116,12 +173,13 @@ func TestLoadConfigPartialValues(t *testing.T) {
 }
 
 func TestUnsupportedType(t *testing.T) {
-	// Define a config with an unsupported type (complex64)
+	// Define a config with an unsupported type (chan)
 	type UnsupportedConfig struct {
-		Complex complex64 `env:"COMPLEX"`
+		Channel chan int `env:"CHANNEL"`
 	}
 
-	os.Setenv("COMPLEX", "1+2i")
+	os.Setenv("CHANNEL", "1")
+	defer os.Unsetenv("CHANNEL")
 
 	cfg := &UnsupportedConfig{}
 	err := LoadConfig(cfg)
@@ -129,6 +187,254 @@ func TestUnsupportedType(t *testing.T) {
 	assert.Contains(t, err.Error(), "unsupported type")
 }
 
+func TestWithSkipUnsupported(t *testing.T) {
+	type SkipConfig struct {
+		Port    int        `env:"PORT"`
+		Channel chan int   `env:"CHANNEL"`
+		Queues  []chan int `env:"QUEUES"`
+	}
+	type RequiredConfig struct {
+		Channel chan int `env:"CHANNEL" required:"true"`
+	}
+
+	var warnings []string
+	loader := NewEnvLoader(
+		WithSkipUnsupported(),
+		WithLogger(func(msg string) { warnings = append(warnings, msg) }),
+	)
+	values := map[string]string{"PORT": "8080", "CHANNEL": "1", "QUEUES": "a,b"}
+
+	cfg := &SkipConfig{}
+	err := loader.LoadFromMap(values, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, 8080, cfg.Port)
+	assert.Nil(t, cfg.Channel)
+	assert.Nil(t, cfg.Queues)
+	assert.Equal(t, []string{
+		"config: skipping CHANNEL (field Channel): unsupported type: chan",
+		"config: skipping QUEUES (field Queues): unsupported slice element type: chan",
+	}, warnings)
+
+	// Required fields still fail, by tag or strict mode
+	err = loader.LoadFromMap(values, &RequiredConfig{})
+	assert.ErrorContains(t, err, "env CHANNEL (field Channel): unsupported type: chan")
+
+	strict := NewEnvLoader(WithSkipUnsupported(), WithStrictMode(), WithLogger(nil))
+	err = strict.LoadFromMap(values, &SkipConfig{})
+	assert.ErrorContains(t, err, "unsupported type: chan")
+}
+
+func TestLoadConfigSizedInts(t *testing.T) {
+	type SizedConfig struct {
+		Small  int8  `env:"SIZED_INT8"`
+		Medium int16 `env:"SIZED_INT16"`
+		Large  int32 `env:"SIZED_INT32"`
+	}
+
+	os.Setenv("SIZED_INT8", "-128")
+	os.Setenv("SIZED_INT16", "32767")
+	os.Setenv("SIZED_INT32", "-2147483648")
+	defer os.Unsetenv("SIZED_INT8")
+	defer os.Unsetenv("SIZED_INT16")
+	defer os.Unsetenv("SIZED_INT32")
+
+	cfg := &SizedConfig{}
+	err := LoadConfig(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, int8(-128), cfg.Small)
+	assert.Equal(t, int16(32767), cfg.Medium)
+	assert.Equal(t, int32(-2147483648), cfg.Large)
+
+	// Overflow must error rather than wrap
+	os.Setenv("SIZED_INT8", "200")
+	err = LoadConfig(&SizedConfig{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "out of range")
+}
+
+// LogLevel is a custom enum type that parses itself from text
+type LogLevel int
+
+const (
+	LogLevelInfo LogLevel = iota
+	LogLevelDebug
+)
+
+func (l *LogLevel) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "INFO":
+		*l = LogLevelInfo
+	case "DEBUG":
+		*l = LogLevelDebug
+	default:
+		return fmt.Errorf("unknown log level: %s", text)
+	}
+	return nil
+}
+
+func TestTextUnmarshalerFields(t *testing.T) {
+	type LevelConfig struct {
+		Level    LogLevel `env:"LOG_LEVEL"`
+		Fallback LogLevel `env:"LOG_LEVEL_FALLBACK" default:"DEBUG"`
+		Unset    LogLevel `env:"LOG_LEVEL_UNSET"`
+		Required LogLevel `env:"LOG_LEVEL_REQUIRED" required:"true"`
+	}
+
+	os.Setenv("LOG_LEVEL", "DEBUG")
+	os.Unsetenv("LOG_LEVEL_FALLBACK")
+	os.Unsetenv("LOG_LEVEL_UNSET")
+	os.Setenv("LOG_LEVEL_REQUIRED", "DEBUG")
+	defer os.Unsetenv("LOG_LEVEL")
+	defer os.Unsetenv("LOG_LEVEL_REQUIRED")
+
+	cfg := &LevelConfig{Unset: LogLevelDebug}
+	err := LoadConfig(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, LogLevelDebug, cfg.Level)
+	assert.Equal(t, LogLevelDebug, cfg.Fallback)
+	assert.Equal(t, LogLevelDebug, cfg.Unset) // empty value skips unmarshaling
+	assert.Equal(t, LogLevelDebug, cfg.Required)
+
+	os.Setenv("LOG_LEVEL", "VERBOSE")
+	err = LoadConfig(&LevelConfig{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown log level")
+}
+
+// Level is a string enum that validates itself when unmarshaled from text
+type Level string
+
+func (l *Level) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "info", "debug":
+		*l = Level(text)
+		return nil
+	}
+	return fmt.Errorf("unknown level: %s", text)
+}
+
+func TestTextUnmarshalerElements(t *testing.T) {
+	type ElementConfig struct {
+		Levels    []Level          `env:"LEVELS"`
+		Fixed     [2]Level         `env:"FIXED"`
+		Modules   map[string]Level `env:"MODULES"`
+		LogLevels []LogLevel       `env:"LOG_LEVELS"`
+	}
+
+	cfg := &ElementConfig{}
+	err := NewEnvLoader().LoadFromMap(map[string]string{
+		"LEVELS":     "info,debug",
+		"FIXED":      "debug,info",
+		"MODULES":    "db=debug,http=info",
+		"LOG_LEVELS": "DEBUG,INFO",
+	}, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, []Level{"info", "debug"}, cfg.Levels)
+	assert.Equal(t, [2]Level{"debug", "info"}, cfg.Fixed)
+	assert.Equal(t, map[string]Level{"db": "debug", "http": "info"}, cfg.Modules)
+	assert.Equal(t, []LogLevel{LogLevelDebug, LogLevelInfo}, cfg.LogLevels)
+
+	for key, value := range map[string]string{"LEVELS": "info,bogus", "FIXED": "bogus,info", "MODULES": "db=bogus"} {
+		err := NewEnvLoader().LoadFromMap(map[string]string{key: value}, &ElementConfig{})
+		assert.ErrorContains(t, err, "unknown level: bogus", key)
+	}
+}
+
+func TestPointerFields(t *testing.T) {
+	type DatabaseConfig struct {
+		Host string `env:"PTR_DB_HOST" default:"localhost"`
+	}
+
+	type PointerConfig struct {
+		Timeout  *int    `env:"PTR_TIMEOUT" min:"1"`
+		Name     *string `env:"PTR_NAME"`
+		Enabled  *bool   `env:"PTR_ENABLED" required:"true"`
+		Database *DatabaseConfig
+	}
+
+	t.Run("present", func(t *testing.T) {
+		os.Setenv("PTR_TIMEOUT", "30")
+		os.Setenv("PTR_NAME", "")
+		os.Setenv("PTR_ENABLED", "false")
+		defer os.Unsetenv("PTR_TIMEOUT")
+		defer os.Unsetenv("PTR_NAME")
+		defer os.Unsetenv("PTR_ENABLED")
+
+		cfg := &PointerConfig{}
+		err := LoadConfig(cfg)
+		assert.NoError(t, err)
+		if assert.NotNil(t, cfg.Timeout) {
+			assert.Equal(t, 30, *cfg.Timeout)
+		}
+		// An explicit false satisfies required because the pointer is set
+		if assert.NotNil(t, cfg.Enabled) {
+			assert.False(t, *cfg.Enabled)
+		}
+		assert.Nil(t, cfg.Name)
+		if assert.NotNil(t, cfg.Database) {
+			assert.Equal(t, "localhost", cfg.Database.Host)
+		}
+	})
+
+	t.Run("absent", func(t *testing.T) {
+		os.Unsetenv("PTR_TIMEOUT")
+		os.Unsetenv("PTR_NAME")
+		os.Setenv("PTR_ENABLED", "true")
+		defer os.Unsetenv("PTR_ENABLED")
+
+		cfg := &PointerConfig{}
+		err := LoadConfig(cfg)
+		assert.NoError(t, err)
+		assert.Nil(t, cfg.Timeout)
+		assert.Nil(t, cfg.Name)
+		if assert.NotNil(t, cfg.Enabled) {
+			assert.True(t, *cfg.Enabled)
+		}
+	})
+
+	t.Run("required pointer unset", func(t *testing.T) {
+		os.Unsetenv("PTR_ENABLED")
+
+		err := LoadConfig(&PointerConfig{})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), ErrRequiredField)
+	})
+
+	t.Run("pointed-to value validated", func(t *testing.T) {
+		os.Setenv("PTR_TIMEOUT", "0")
+		os.Setenv("PTR_ENABLED", "true")
+		defer os.Unsetenv("PTR_TIMEOUT")
+		defer os.Unsetenv("PTR_ENABLED")
+
+		err := LoadConfig(&PointerConfig{})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), ErrOutOfRange)
+	})
+}
+
+func TestMapFields(t *testing.T) {
+	type MapConfig struct {
+		Labels map[string]string `env:"MAP_LABELS" required:"true"`
+		Limits map[string]int    `env:"MAP_LIMITS"`
+	}
+
+	os.Setenv("MAP_LABELS", "env=prod,team=core")
+	os.Unsetenv("MAP_LIMITS")
+	defer os.Unsetenv("MAP_LABELS")
+
+	cfg := &MapConfig{}
+	err := LoadConfig(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"env": "prod", "team": "core"}, cfg.Labels)
+	assert.Nil(t, cfg.Limits)
+
+	os.Setenv("MAP_LIMITS", "cpu")
+	defer os.Unsetenv("MAP_LIMITS")
+	err = LoadConfig(&MapConfig{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "expected key=value")
+}
+
 func TestIsTimeType(t *testing.T) {
 	// Test with time.Time
 	assert.True(t, isTimeType(reflect.TypeOf(time.Time{})))
@@ -201,6 +507,66 @@ func TestWithParser(t *testing.T) {
 	assert.Equal(t, mockParser, parser)
 }
 
+type logLevel string
+
+// upperParser stores values upper-cased, for checking which parser ran
+type upperParser struct{}
+
+func (p *upperParser) Parse(value string, field reflect.Value) error {
+	field.SetString(strings.ToUpper(value))
+	return nil
+}
+
+type hostPort struct {
+	Host string
+	Port int
+}
+
+type hostPortParser struct{}
+
+func (p *hostPortParser) Parse(value string, field reflect.Value) error {
+	host, port, err := net.SplitHostPort(value)
+	if err != nil {
+		return err
+	}
+	n, err := strconv.Atoi(port)
+	if err != nil {
+		return err
+	}
+	field.Set(reflect.ValueOf(hostPort{Host: host, Port: n}))
+	return nil
+}
+
+func TestWithTypeParser(t *testing.T) {
+	type TypedConfig struct {
+		Level logLevel `env:"TYPED_LEVEL"`
+		Name  string   `env:"TYPED_NAME"`
+		Addr  hostPort `env:"TYPED_ADDR"`
+	}
+
+	loader := NewEnvLoader(
+		WithTypeParser(reflect.TypeOf(logLevel("")), &upperParser{}),
+		WithTypeParser(reflect.TypeOf(hostPort{}), &hostPortParser{}),
+	)
+
+	cfg := &TypedConfig{}
+	err := loader.LoadFromMap(map[string]string{
+		"TYPED_LEVEL": "debug",
+		"TYPED_NAME":  "debug",
+		"TYPED_ADDR":  "db.local:5432",
+	}, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, logLevel("DEBUG"), cfg.Level)
+	assert.Equal(t, "debug", cfg.Name)
+	assert.Equal(t, hostPort{Host: "db.local", Port: 5432}, cfg.Addr)
+
+	// Other loaders are unaffected
+	cfg = &TypedConfig{}
+	err = NewEnvLoader().LoadFromMap(map[string]string{"TYPED_LEVEL": "debug"}, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, logLevel("debug"), cfg.Level)
+}
+
 func TestWithValidator(t *testing.T) {
 	// Create a mock validator
 	mockValidator := &RequiredValidator{}
@@ -221,6 +587,43 @@ func TestWithValidator(t *testing.T) {
 	assert.True(t, found)
 }
 
+// recordingValidator records the kinds of the fields it is called for
+type recordingValidator struct {
+	kinds []reflect.Kind
+}
+
+func (v *recordingValidator) Validate(field reflect.Value, tags reflect.StructTag) error {
+	v.kinds = append(v.kinds, field.Kind())
+	if field.Kind() == reflect.String && field.String() == "bad" {
+		return errors.New("bad string")
+	}
+	return nil
+}
+
+func TestWithValidatorForKind(t *testing.T) {
+	type KindConfig struct {
+		Name  string  `env:"NAME"`
+		Port  int     `env:"PORT"`
+		Alias *string `env:"ALIAS"`
+	}
+
+	scoped := &recordingValidator{}
+	global := &recordingValidator{}
+	loader := NewEnvLoader(
+		WithValidatorForKind(reflect.String, scoped),
+		WithValidator(global),
+	)
+
+	err := loader.LoadFromMap(map[string]string{"NAME": "api", "PORT": "80", "ALIAS": "a"}, &KindConfig{})
+	assert.NoError(t, err)
+	assert.Equal(t, []reflect.Kind{reflect.String, reflect.Ptr}, scoped.kinds) // skipped for the int field
+	assert.Equal(t, []reflect.Kind{reflect.String, reflect.Int, reflect.Ptr}, global.kinds)
+
+	err = NewEnvLoader(WithValidatorForKind(reflect.String, &recordingValidator{})).
+		LoadFromMap(map[string]string{"NAME": "bad"}, &KindConfig{})
+	assert.ErrorContains(t, err, "env NAME (field Name): bad string")
+}
+
 func TestWithPrefix(t *testing.T) {
 	// Create loader with prefix
 	prefix := "APP_"
@@ -243,3 +646,1749 @@ func TestWithPrefix(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "value", cfg.Test)
 }
+
+func TestWithPrefixSeparator(t *testing.T) {
+	type BackendConfig struct {
+		Host string `env:"HOST"`
+	}
+	type SepConfig struct {
+		Port int `env:"PORT"`
+		DB   struct {
+			Host string `env:"HOST"`
+		} `envPrefix:"DB"`
+		Cache struct {
+			Host string `env:"HOST"`
+		} `envPrefix:"CACHE_"`
+		Backends []BackendConfig `envPrefix:"BACKEND"`
+	}
+
+	values := map[string]string{
+		"APP_PORT":           "8080",
+		"APP_DB_HOST":        "db",
+		"APP_CACHE_HOST":     "cache",
+		"APP_BACKEND_0_HOST": "b0",
+	}
+
+	for _, prefix := range []string{"APP", "APP_", "_APP__"} {
+		t.Run(prefix, func(t *testing.T) {
+			loader := NewEnvLoader(WithPrefix(prefix), WithPrefixSeparator("_"))
+			assert.Equal(t, "APP_", loader.prefix)
+
+			cfg := &SepConfig{}
+			err := loader.LoadFromMap(values, cfg)
+			assert.NoError(t, err)
+			assert.Equal(t, 8080, cfg.Port)
+			assert.Equal(t, "db", cfg.DB.Host)
+			assert.Equal(t, "cache", cfg.Cache.Host)
+			assert.Equal(t, []BackendConfig{{Host: "b0"}}, cfg.Backends)
+		})
+	}
+
+	// Option order does not matter
+	loader := NewEnvLoader(WithPrefixSeparator("_"), WithPrefixes("APP", ""))
+	assert.Equal(t, "APP_", loader.prefix)
+	assert.Equal(t, []string{""}, loader.fallbackPrefixes)
+
+	// Without a separator prefixes are concatenated as written
+	loader = NewEnvLoader(WithPrefix("APP"))
+	cfg := &SepConfig{}
+	err := loader.LoadFromMap(map[string]string{"APPPORT": "9090"}, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, 9090, cfg.Port)
+}
+
+func TestWithKeyFunc(t *testing.T) {
+	type KeyConfig struct {
+		Port int    `env:"PORT" default:"8080"`
+		Host string `env:"HOST"`
+		Name string
+	}
+
+	region := "EU"
+	var seen []string
+	loader := NewEnvLoader(
+		WithPrefix("IGNORED_"),
+		WithKeyFunc(func(fieldName, tag string) string {
+			seen = append(seen, fieldName+"="+tag)
+			if fieldName == "Host" {
+				return ""
+			}
+			return tag + "_" + region
+		}),
+	)
+
+	cfg := &KeyConfig{}
+	err := loader.LoadFromMap(map[string]string{
+		"PORT_EU":         "9090",
+		"IGNORED_PORT":    "7070",
+		"HOST_EU":         "skipped",
+		"IGNORED_PORT_EU": "6060",
+	}, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, 9090, cfg.Port)
+	assert.Empty(t, cfg.Host) // empty key skips the field
+	assert.Contains(t, seen, "Port=PORT")
+	assert.Contains(t, seen, "Host=HOST")
+
+	// The computed key is used for defaults and errors too
+	region = "US"
+	cfg = &KeyConfig{}
+	err = loader.LoadFromMap(nil, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, 8080, cfg.Port)
+
+	err = loader.LoadFromMap(map[string]string{"PORT_US": "abc"}, &KeyConfig{})
+	assert.ErrorContains(t, err, "env PORT_US")
+}
+
+func TestWithKeyFunc_StructSlice(t *testing.T) {
+	type Backend struct {
+		Host string `env:"HOST"`
+	}
+	type SliceConfig struct {
+		Backends []Backend `envPrefix:"BACKEND_"`
+	}
+
+	loader := NewEnvLoader(WithKeyFunc(func(fieldName, tag string) string {
+		return tag
+	}))
+
+	cfg := &SliceConfig{}
+	err := loader.LoadFromMap(map[string]string{"HOST": "x"}, cfg)
+	assert.ErrorContains(t, err, ErrKeyFuncIndexed)
+	assert.Nil(t, cfg.Backends)
+
+	// Unset slices are left alone
+	err = loader.LoadFromMap(nil, cfg)
+	assert.NoError(t, err)
+}
+
+func TestWithPrefixes(t *testing.T) {
+	type RoleConfig struct {
+		Port int    `env:"PORT" default:"8080"`
+		Host string `env:"HOST" default:"localhost"`
+		DB   struct {
+			Name string `env:"NAME"`
+		} `envPrefix:"DB_"`
+	}
+
+	loader := NewEnvLoader(WithPrefixes("PRIMARY_", ""))
+
+	cfg := &RoleConfig{}
+	err := loader.LoadFromMap(map[string]string{
+		"PRIMARY_PORT": "9090",
+		"PORT":         "7070",
+		"DB_NAME":      "shared",
+	}, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, 9090, cfg.Port)        // primary prefix wins
+	assert.Equal(t, "localhost", cfg.Host) // default when neither is set
+	assert.Equal(t, "shared", cfg.DB.Name) // nested names fall back too
+
+	cfg = &RoleConfig{}
+	err = loader.LoadFromMap(map[string]string{"PORT": "7070"}, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, 7070, cfg.Port) // falls back to the unprefixed variable
+
+	// Errors name the primary key
+	err = loader.LoadFromMap(map[string]string{"PORT": "abc"}, &RoleConfig{})
+	assert.ErrorContains(t, err, "env PRIMARY_PORT")
+
+	// WithPrefix replaces the list
+	loader = NewEnvLoader(WithPrefixes("PRIMARY_", ""), WithPrefix("APP_"))
+	cfg = &RoleConfig{}
+	err = loader.LoadFromMap(map[string]string{"PORT": "7070"}, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, 8080, cfg.Port)
+}
+
+func TestWithErrorAggregation(t *testing.T) {
+	type DatabaseConfig struct {
+		Host string `env:"AGG_DB_HOST" required:"true"`
+	}
+
+	type AggConfig struct {
+		Port     int `env:"AGG_PORT"`
+		Database DatabaseConfig
+		Ratio    float64 `env:"AGG_RATIO"`
+	}
+
+	os.Setenv("AGG_PORT", "not-a-number")
+	os.Unsetenv("AGG_DB_HOST")
+	os.Setenv("AGG_RATIO", "not-a-float")
+	defer os.Unsetenv("AGG_PORT")
+	defer os.Unsetenv("AGG_RATIO")
+
+	// Without aggregation only the first error is reported
+	err := LoadConfig(&AggConfig{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "field Port")
+	assert.NotContains(t, err.Error(), "Database.Host")
+
+	loader := NewEnvLoader(WithErrorAggregation())
+	err = loader.LoadConfig(&AggConfig{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "env AGG_PORT (field Port):")
+	assert.Contains(t, err.Error(), "env AGG_DB_HOST (field Database.Host): "+ErrRequiredField)
+	assert.Contains(t, err.Error(), "env AGG_RATIO (field Ratio):")
+
+	joined, ok := err.(interface{ Unwrap() []error })
+	assert.True(t, ok)
+	assert.Len(t, joined.Unwrap(), 3)
+
+	var numErr *strconv.NumError
+	assert.True(t, errors.As(err, &numErr))
+}
+
+func TestWithAutoEnvNames(t *testing.T) {
+	type AutoConfig struct {
+		DatabaseURL string
+		HTTPPort    int
+		Explicit    string `env:"AUTO_EXPLICIT"`
+		internal    string
+	}
+
+	os.Setenv("DATABASE_URL", "postgres://localhost/app")
+	os.Setenv("HTTP_PORT", "8080")
+	os.Setenv("AUTO_EXPLICIT", "from-tag")
+	os.Setenv("EXPLICIT", "from-name")
+	defer os.Unsetenv("DATABASE_URL")
+	defer os.Unsetenv("HTTP_PORT")
+	defer os.Unsetenv("AUTO_EXPLICIT")
+	defer os.Unsetenv("EXPLICIT")
+
+	cfg := &AutoConfig{}
+	loader := NewEnvLoader(WithAutoEnvNames())
+	err := loader.LoadConfig(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, "postgres://localhost/app", cfg.DatabaseURL)
+	assert.Equal(t, 8080, cfg.HTTPPort)
+	assert.Equal(t, "from-tag", cfg.Explicit)
+	assert.Empty(t, cfg.internal)
+
+	// Without the option untagged fields are ignored
+	plain := &AutoConfig{}
+	err = NewEnvLoader().LoadConfig(plain)
+	assert.NoError(t, err)
+	assert.Empty(t, plain.DatabaseURL)
+}
+
+func TestNestedEnvPrefix(t *testing.T) {
+	type PoolConfig struct {
+		Size int `env:"SIZE"`
+	}
+
+	type DatabaseConfig struct {
+		Host string     `env:"HOST"`
+		Pool PoolConfig `envPrefix:"POOL_"`
+	}
+
+	type PrefixedConfig struct {
+		Database DatabaseConfig  `envPrefix:"DB_"`
+		Replica  *DatabaseConfig `envPrefix:"REPLICA_"`
+		Host     string          `env:"HOST"`
+	}
+
+	os.Setenv("APP_HOST", "app-host")
+	os.Setenv("APP_DB_HOST", "db-host")
+	os.Setenv("APP_DB_POOL_SIZE", "10")
+	os.Setenv("APP_REPLICA_HOST", "replica-host")
+	defer os.Unsetenv("APP_HOST")
+	defer os.Unsetenv("APP_DB_HOST")
+	defer os.Unsetenv("APP_DB_POOL_SIZE")
+	defer os.Unsetenv("APP_REPLICA_HOST")
+
+	t.Run("single level", func(t *testing.T) {
+		os.Setenv("DB_HOST", "plain-db-host")
+		defer os.Unsetenv("DB_HOST")
+
+		cfg := &PrefixedConfig{}
+		err := LoadConfig(cfg)
+		assert.NoError(t, err)
+		assert.Equal(t, "plain-db-host", cfg.Database.Host)
+	})
+
+	t.Run("composed with loader prefix", func(t *testing.T) {
+		cfg := &PrefixedConfig{}
+		err := NewEnvLoader(WithPrefix("APP_")).LoadConfig(cfg)
+		assert.NoError(t, err)
+		assert.Equal(t, "db-host", cfg.Database.Host)
+		assert.Equal(t, 10, cfg.Database.Pool.Size)
+		assert.Equal(t, "replica-host", cfg.Replica.Host)
+		// The prefix is restored after leaving a nested struct
+		assert.Equal(t, "app-host", cfg.Host)
+	})
+}
+
+func TestRequiredNestedStruct(t *testing.T) {
+	type DatabaseConfig struct {
+		Host string `env:"HOST"`
+		Port int    `env:"PORT"`
+	}
+	type RequiredBlockConfig struct {
+		Database DatabaseConfig  `envPrefix:"DB_" required:"true"`
+		Replica  *DatabaseConfig `envPrefix:"REPLICA_" required:"true"`
+		Cache    DatabaseConfig  `envPrefix:"CACHE_"`
+	}
+
+	tests := []struct {
+		name    string
+		values  map[string]string
+		wantErr string
+	}{
+		{
+			name:   "partially populated",
+			values: map[string]string{"APP_DB_HOST": "db.local", "APP_REPLICA_PORT": "5433"},
+		},
+		{
+			name:    "empty nested struct",
+			values:  map[string]string{"APP_REPLICA_HOST": "replica.local"},
+			wantErr: "env APP_DB_* (field Database): " + ErrRequiredBlock,
+		},
+		{
+			name:    "empty nested pointer",
+			values:  map[string]string{"APP_DB_HOST": "db.local"},
+			wantErr: "env APP_REPLICA_* (field Replica): " + ErrRequiredBlock,
+		},
+	}
+
+	loader := NewEnvLoader(WithPrefix("APP_"))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := loader.LoadFromMap(tt.values, &RequiredBlockConfig{})
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+
+			var fieldErr *FieldError
+			assert.ErrorAs(t, err, &fieldErr)
+			assert.Equal(t, KindRequired, fieldErr.Kind)
+		})
+	}
+
+	t.Run("validate", func(t *testing.T) {
+		err := loader.Validate(&RequiredBlockConfig{})
+		assert.ErrorContains(t, err, "env APP_DB_* (field Database): "+ErrRequiredBlock)
+		assert.ErrorContains(t, err, "env APP_REPLICA_* (field Replica): "+ErrRequiredBlock)
+
+		err = loader.Validate(&RequiredBlockConfig{
+			Database: DatabaseConfig{Host: "db.local"},
+			Replica:  &DatabaseConfig{Port: 5433},
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	type DatabaseConfig struct {
+		Host string `env:"VALIDATE_DB_HOST" required:"true"`
+	}
+
+	type ValidateConfig struct {
+		Port     int    `env:"VALIDATE_PORT" required:"true" min:"1" max:"65535"`
+		Env      string `env:"VALIDATE_ENV" oneof:"dev prod"`
+		Database DatabaseConfig
+	}
+
+	// Values in the environment must be ignored
+	os.Setenv("VALIDATE_PORT", "8080")
+	os.Setenv("VALIDATE_DB_HOST", "db.local")
+	defer os.Unsetenv("VALIDATE_PORT")
+	defer os.Unsetenv("VALIDATE_DB_HOST")
+
+	t.Run("valid", func(t *testing.T) {
+		cfg := &ValidateConfig{
+			Port:     443,
+			Env:      "prod",
+			Database: DatabaseConfig{Host: "localhost"},
+		}
+		assert.NoError(t, Validate(cfg))
+		assert.Equal(t, 443, cfg.Port)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		cfg := ValidateConfig{Env: "staging"}
+		err := NewEnvLoader().Validate(cfg)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "env VALIDATE_PORT (field Port): "+ErrRequiredField)
+		assert.Contains(t, err.Error(), "env VALIDATE_ENV (field Env): "+ErrNotOneOf)
+		assert.Contains(t, err.Error(), "env VALIDATE_DB_HOST (field Database.Host): "+ErrRequiredField)
+	})
+
+	t.Run("not a struct", func(t *testing.T) {
+		assert.EqualError(t, Validate("config"), ErrValidateNotStruct)
+	})
+}
+
+func TestNetworkFields(t *testing.T) {
+	type NetConfig struct {
+		BindAddr net.IP    `env:"NET_BIND_ADDR"`
+		Listen6  net.IP    `env:"NET_LISTEN6"`
+		Unset    net.IP    `env:"NET_UNSET"`
+		Subnet   net.IPNet `env:"NET_SUBNET" required:"true"`
+	}
+
+	os.Setenv("NET_BIND_ADDR", "10.1.2.3")
+	os.Setenv("NET_LISTEN6", "::1")
+	os.Unsetenv("NET_UNSET")
+	os.Setenv("NET_SUBNET", "192.168.0.0/16")
+	defer os.Unsetenv("NET_BIND_ADDR")
+	defer os.Unsetenv("NET_LISTEN6")
+	defer os.Unsetenv("NET_SUBNET")
+
+	cfg := &NetConfig{}
+	err := LoadConfig(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, "10.1.2.3", cfg.BindAddr.String())
+	assert.Equal(t, "::1", cfg.Listen6.String())
+	assert.Nil(t, cfg.Unset)
+	assert.Equal(t, "192.168.0.0/16", cfg.Subnet.String())
+
+	os.Setenv("NET_BIND_ADDR", "not-an-ip")
+	err = LoadConfig(&NetConfig{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "env NET_BIND_ADDR (field BindAddr): invalid IP address")
+}
+
+func TestURLFields(t *testing.T) {
+	type URLConfig struct {
+		Endpoint url.URL  `env:"URL_ENDPOINT"`
+		Callback *url.URL `env:"URL_CALLBACK" require_scheme:"true"`
+		Unset    *url.URL `env:"URL_UNSET"`
+	}
+
+	os.Setenv("URL_ENDPOINT", "https://host/path?x=1")
+	os.Setenv("URL_CALLBACK", "http://callback.local/hook")
+	os.Unsetenv("URL_UNSET")
+	defer os.Unsetenv("URL_ENDPOINT")
+	defer os.Unsetenv("URL_CALLBACK")
+
+	cfg := &URLConfig{}
+	err := LoadConfig(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, "https", cfg.Endpoint.Scheme)
+	assert.Equal(t, "host", cfg.Endpoint.Host)
+	assert.Equal(t, "/path", cfg.Endpoint.Path)
+	assert.Equal(t, "1", cfg.Endpoint.Query().Get("x"))
+	if assert.NotNil(t, cfg.Callback) {
+		assert.Equal(t, "callback.local", cfg.Callback.Host)
+	}
+	assert.Nil(t, cfg.Unset)
+
+	t.Run("scheme-less without tag", func(t *testing.T) {
+		os.Setenv("URL_ENDPOINT", "host/path")
+		defer os.Setenv("URL_ENDPOINT", "https://host/path?x=1")
+
+		cfg := &URLConfig{}
+		assert.NoError(t, LoadConfig(cfg))
+		assert.Equal(t, "host/path", cfg.Endpoint.Path)
+	})
+
+	t.Run("scheme-less with tag", func(t *testing.T) {
+		os.Setenv("URL_CALLBACK", "callback.local/hook")
+		defer os.Setenv("URL_CALLBACK", "http://callback.local/hook")
+
+		err := LoadConfig(&URLConfig{})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), ErrMissingScheme)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		os.Setenv("URL_ENDPOINT", "http://[::1")
+		defer os.Setenv("URL_ENDPOINT", "https://host/path?x=1")
+
+		err := LoadConfig(&URLConfig{})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "field Endpoint")
+	})
+}
+
+func TestTimeFields(t *testing.T) {
+	type TimeConfig struct {
+		Start    time.Time  `env:"TIME_START"`
+		Date     time.Time  `env:"TIME_DATE" layout:"2006-01-02"`
+		Deadline *time.Time `env:"TIME_DEADLINE"`
+		Unset    time.Time  `env:"TIME_UNSET"`
+	}
+
+	os.Setenv("TIME_START", "2024-03-01T10:30:00Z")
+	os.Setenv("TIME_DATE", "2024-12-25")
+	os.Unsetenv("TIME_DEADLINE")
+	os.Unsetenv("TIME_UNSET")
+	defer os.Unsetenv("TIME_START")
+	defer os.Unsetenv("TIME_DATE")
+
+	cfg := &TimeConfig{}
+	err := LoadConfig(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 1, 10, 30, 0, 0, time.UTC), cfg.Start)
+	assert.Equal(t, time.Date(2024, 12, 25, 0, 0, 0, 0, time.UTC), cfg.Date)
+	assert.Nil(t, cfg.Deadline)
+	assert.True(t, cfg.Unset.IsZero())
+
+	os.Setenv("TIME_DATE", "25/12/2024")
+	err = LoadConfig(&TimeConfig{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "field Date")
+}
+
+func TestWithCaseInsensitive(t *testing.T) {
+	type CaseConfig struct {
+		Port int    `env:"CI_PORT"`
+		Host string `env:"CI_HOST"`
+	}
+
+	os.Setenv("ci_port", "8080")
+	os.Setenv("ci_host", "lower-host")
+	os.Setenv("CI_HOST", "exact-host")
+	defer os.Unsetenv("ci_port")
+	defer os.Unsetenv("ci_host")
+	defer os.Unsetenv("CI_HOST")
+
+	cfg := &CaseConfig{}
+	err := NewEnvLoader(WithCaseInsensitive()).LoadConfig(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, 8080, cfg.Port)
+	// The exact-case variable wins over a case-insensitive match
+	assert.Equal(t, "exact-host", cfg.Host)
+
+	// Default loader stays case-sensitive
+	strict := &CaseConfig{}
+	err = LoadConfig(strict)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, strict.Port)
+}
+
+func TestLoadFromMap(t *testing.T) {
+	type DatabaseConfig struct {
+		Host string `env:"HOST" required:"true"`
+	}
+
+	type MapSourceConfig struct {
+		Port     int            `env:"PORT" default:"8080"`
+		Name     string         `env:"NAME"`
+		Database DatabaseConfig `envPrefix:"DB_"`
+	}
+
+	// The process environment must not be consulted
+	os.Setenv("APP_NAME", "from-env")
+	defer os.Unsetenv("APP_NAME")
+
+	values := map[string]string{
+		"APP_DB_HOST": "db.local",
+	}
+
+	cfg := &MapSourceConfig{}
+	err := NewEnvLoader(WithPrefix("APP_")).LoadFromMap(values, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, "db.local", cfg.Database.Host)
+	assert.Equal(t, 8080, cfg.Port) // default applies for missing keys
+	assert.Empty(t, cfg.Name)
+
+	err = NewEnvLoader().LoadFromMap(map[string]string{}, &MapSourceConfig{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "field Database.Host")
+}
+
+func TestRequiredIf(t *testing.T) {
+	type TLSConfig struct {
+		// TLSCert is declared before the field it depends on
+		TLSCert    string `env:"RIF_TLS_CERT" required_if:"TLSEnabled=true"`
+		TLSEnabled bool   `env:"RIF_TLS_ENABLED"`
+	}
+
+	os.Unsetenv("RIF_TLS_CERT")
+
+	t.Run("condition met", func(t *testing.T) {
+		os.Setenv("RIF_TLS_ENABLED", "true")
+		defer os.Unsetenv("RIF_TLS_ENABLED")
+
+		err := LoadConfig(&TLSConfig{})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "env RIF_TLS_CERT (field TLSCert): "+ErrRequiredField)
+	})
+
+	t.Run("condition not met", func(t *testing.T) {
+		os.Setenv("RIF_TLS_ENABLED", "false")
+		defer os.Unsetenv("RIF_TLS_ENABLED")
+
+		assert.NoError(t, LoadConfig(&TLSConfig{}))
+	})
+
+	t.Run("validate", func(t *testing.T) {
+		assert.Error(t, Validate(&TLSConfig{TLSEnabled: true}))
+		assert.NoError(t, Validate(&TLSConfig{TLSEnabled: true, TLSCert: "/cert.pem"}))
+	})
+}
+
+func TestRequiredCustomError(t *testing.T) {
+	type DatabaseConfig struct {
+		URL string `env:"REQERR_DATABASE_URL" required:"true" required_error:"DATABASE_URL must be set"`
+	}
+
+	type RequiredErrConfig struct {
+		Database DatabaseConfig
+	}
+
+	os.Unsetenv("REQERR_DATABASE_URL")
+
+	err := LoadConfig(&RequiredErrConfig{})
+	assert.EqualError(t, err, "env REQERR_DATABASE_URL (field Database.URL): DATABASE_URL must be set")
+}
+
+func TestErrorIncludesEnvName(t *testing.T) {
+	type DatabaseConfig struct {
+		Port int `env:"PORT"`
+	}
+
+	type EnvNameConfig struct {
+		Port     int            `env:"PORT"`
+		Database DatabaseConfig `envPrefix:"DB_"`
+	}
+
+	os.Setenv("APP_PORT", "abc")
+	defer os.Unsetenv("APP_PORT")
+
+	err := NewEnvLoader(WithPrefix("APP_")).LoadConfig(&EnvNameConfig{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "env APP_PORT (field Port): ")
+	assert.Contains(t, err.Error(), `parsing "abc"`)
+
+	os.Setenv("APP_PORT", "8080")
+	os.Setenv("APP_DB_PORT", "abc")
+	defer os.Unsetenv("APP_DB_PORT")
+
+	err = NewEnvLoader(WithPrefix("APP_")).LoadConfig(&EnvNameConfig{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "env APP_DB_PORT (field Database.Port): ")
+}
+
+func TestComplexFields(t *testing.T) {
+	type ComplexConfig struct {
+		Signal complex128 `env:"COMPLEX_SIGNAL"`
+		Small  complex64  `env:"COMPLEX_SMALL"`
+	}
+
+	os.Setenv("COMPLEX_SIGNAL", "1+2i")
+	os.Setenv("COMPLEX_SMALL", "0.5-1.5i")
+	defer os.Unsetenv("COMPLEX_SIGNAL")
+	defer os.Unsetenv("COMPLEX_SMALL")
+
+	cfg := &ComplexConfig{}
+	err := LoadConfig(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, complex(1, 2), cfg.Signal)
+	assert.Equal(t, complex64(complex(0.5, -1.5)), cfg.Small)
+}
+
+func TestTypedSliceFields(t *testing.T) {
+	type SliceConfig struct {
+		Backoff []time.Duration `env:"TYPED_BACKOFF"`
+		Flags   []bool          `env:"TYPED_FLAGS"`
+		Weights []float64       `env:"TYPED_WEIGHTS"`
+	}
+
+	os.Setenv("TYPED_BACKOFF", "5s,10s,1m")
+	os.Setenv("TYPED_FLAGS", "true,false")
+	os.Setenv("TYPED_WEIGHTS", "0.5,1.5")
+	defer os.Unsetenv("TYPED_BACKOFF")
+	defer os.Unsetenv("TYPED_FLAGS")
+	defer os.Unsetenv("TYPED_WEIGHTS")
+
+	cfg := &SliceConfig{}
+	err := LoadConfig(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Duration{5 * time.Second, 10 * time.Second, time.Minute}, cfg.Backoff)
+	assert.Equal(t, []bool{true, false}, cfg.Flags)
+	assert.Equal(t, []float64{0.5, 1.5}, cfg.Weights)
+}
+
+func TestTimeCollectionFields(t *testing.T) {
+	type TimeConfig struct {
+		Windows  []time.Time              `env:"WINDOWS"`
+		Holidays []time.Time              `env:"HOLIDAYS" layout:"2006-01-02"`
+		Timeouts map[string]time.Duration `env:"TIMEOUTS"`
+		Backends []hostPort               `env:"BACKENDS"`
+	}
+
+	loader := NewEnvLoader(WithTypeParser(reflect.TypeOf(hostPort{}), &hostPortParser{}))
+
+	cfg := &TimeConfig{}
+	err := loader.LoadFromMap(map[string]string{
+		"WINDOWS":  "2024-01-02T03:04:05Z,2024-06-01T00:00:00+02:00",
+		"HOLIDAYS": "2024-12-25,2024-12-26",
+		"TIMEOUTS": "read=5s,write=1m",
+		"BACKENDS": "a.local:80,b.local:81",
+	}, cfg)
+	assert.NoError(t, err)
+	assert.Len(t, cfg.Windows, 2)
+	assert.True(t, cfg.Windows[0].Equal(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)))
+	assert.True(t, cfg.Windows[1].Equal(time.Date(2024, 5, 31, 22, 0, 0, 0, time.UTC)))
+	assert.Equal(t, []time.Time{
+		time.Date(2024, 12, 25, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 12, 26, 0, 0, 0, 0, time.UTC),
+	}, cfg.Holidays) // layout tag applies to each element
+	assert.Equal(t, map[string]time.Duration{"read": 5 * time.Second, "write": time.Minute}, cfg.Timeouts)
+	assert.Equal(t, []hostPort{{"a.local", 80}, {"b.local", 81}}, cfg.Backends) // WithTypeParser elements
+
+	// An invalid element is a parse error
+	err = loader.LoadFromMap(map[string]string{"WINDOWS": "2024-01-02T03:04:05Z,yesterday"}, &TimeConfig{})
+	var fieldErr *FieldError
+	assert.ErrorAs(t, err, &fieldErr)
+	assert.Equal(t, KindParse, fieldErr.Kind)
+}
+
+func TestBigNumberFields(t *testing.T) {
+	type BigConfig struct {
+		Supply *big.Int   `env:"SUPPLY"`
+		Key    *big.Int   `env:"KEY" base:"16"`
+		Rate   *big.Float `env:"RATE"`
+		Unset  *big.Int   `env:"UNSET"`
+	}
+
+	cfg := &BigConfig{}
+	err := NewEnvLoader().LoadFromMap(map[string]string{
+		"SUPPLY": "21000000000000000000000000",
+		"KEY":    "DEADBEEFCAFEBABE0123",
+		"RATE":   "0.000000000000000001",
+	}, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, "21000000000000000000000000", cfg.Supply.String())
+	assert.Equal(t, "deadbeefcafebabe0123", cfg.Key.Text(16))
+	assert.Equal(t, "1e-18", cfg.Rate.Text('g', 10))
+	assert.Nil(t, cfg.Unset) // empty values leave the pointer nil
+
+	err = NewEnvLoader().LoadFromMap(map[string]string{"SUPPLY": "lots"}, &BigConfig{})
+	var fieldErr *FieldError
+	assert.ErrorAs(t, err, &fieldErr)
+	assert.Equal(t, KindParse, fieldErr.Kind)
+	assert.Equal(t, "SUPPLY", fieldErr.EnvKey)
+}
+
+func TestByteSizeFields(t *testing.T) {
+	type SizeConfig struct {
+		MaxBody  int64   `env:"MAX_BODY" size:"true" default:"1MiB"`
+		Cache    uint64  `env:"CACHE" size:"true"`
+		Raw      int64   `env:"RAW"`
+		Segments []int64 `env:"SEGMENTS" size:"true"`
+	}
+
+	cfg := &SizeConfig{}
+	err := NewEnvLoader().LoadFromMap(map[string]string{
+		"CACHE":    "2GB",
+		"RAW":      "1024",
+		"SEGMENTS": "64KiB,1MB",
+	}, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1<<20), cfg.MaxBody)
+	assert.Equal(t, uint64(2e9), cfg.Cache)
+	assert.Equal(t, int64(1024), cfg.Raw)
+	assert.Equal(t, []int64{64 << 10, 1e6}, cfg.Segments)
+
+	// Without the tag suffixes are rejected
+	err = NewEnvLoader().LoadFromMap(map[string]string{"RAW": "10MB"}, &SizeConfig{})
+	assert.Error(t, err)
+}
+
+func TestOneOfCaseInsensitiveLoad(t *testing.T) {
+	type EnvConfig struct {
+		Env *string `env:"APP_ENV" oneof:"dev staging prod" oneof_ci:"true"`
+	}
+
+	cfg := &EnvConfig{}
+	err := NewEnvLoader().LoadFromMap(map[string]string{"APP_ENV": "Staging"}, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, "staging", *cfg.Env)
+
+	err = NewEnvLoader().LoadFromMap(map[string]string{"APP_ENV": "QA"}, &EnvConfig{})
+	assert.ErrorContains(t, err, ErrNotOneOf)
+}
+
+func TestCaseNormalizedEnum(t *testing.T) {
+	type Level string
+	type CaseConfig struct {
+		Level  Level    `env:"LEVEL" case:"lower" oneof:"debug info warn"`
+		Region string   `env:"REGION" case:"upper" default:"eu"`
+		Tags   []string `env:"TAGS" case:"lower"`
+	}
+
+	cfg := &CaseConfig{}
+	err := NewEnvLoader().LoadFromMap(map[string]string{"LEVEL": "INFO", "TAGS": "A,b"}, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, Level("info"), cfg.Level) // oneof sees the normalized value
+	assert.Equal(t, "EU", cfg.Region)
+	assert.Equal(t, []string{"a", "b"}, cfg.Tags)
+
+	err = NewEnvLoader().LoadFromMap(map[string]string{"LEVEL": "TRACE"}, &CaseConfig{})
+	assert.ErrorContains(t, err, ErrNotOneOf)
+}
+
+func TestCSVSliceFields(t *testing.T) {
+	type CSVConfig struct {
+		Globs []string `env:"GLOBS" csv:"true"`
+	}
+
+	cfg := &CSVConfig{}
+	err := NewEnvLoader().LoadFromMap(map[string]string{"GLOBS": `"*.{go,mod}",*.md`}, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"*.{go,mod}", "*.md"}, cfg.Globs)
+
+	err = NewEnvLoader().LoadFromMap(map[string]string{"GLOBS": `"*.go`}, &CSVConfig{})
+	var fieldErr *FieldError
+	assert.ErrorAs(t, err, &fieldErr)
+	assert.Equal(t, KindParse, fieldErr.Kind)
+}
+
+func TestDedupSliceFields(t *testing.T) {
+	type DedupConfig struct {
+		Origins []string `env:"ORIGINS" dedup:"true"`
+		Raw     []string `env:"RAW"`
+	}
+
+	cfg := &DedupConfig{}
+	err := NewEnvLoader().LoadFromMap(map[string]string{"ORIGINS": "a,b,a,c", "RAW": "a,a"}, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, cfg.Origins)
+	assert.Equal(t, []string{"a", "a"}, cfg.Raw)
+}
+
+func TestBoolCollectionFields(t *testing.T) {
+	type BoolConfig struct {
+		Flags    []bool          `env:"FLAGS"`
+		Features map[string]bool `env:"FEATURES"`
+	}
+
+	cfg := &BoolConfig{}
+	err := NewEnvLoader().LoadFromMap(map[string]string{
+		"FLAGS":    "yes,no,on",
+		"FEATURES": "search=on,beta=off,legacy=1",
+	}, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{true, false, true}, cfg.Flags)
+	assert.Equal(t, map[string]bool{"search": true, "beta": false, "legacy": true}, cfg.Features)
+
+	// Elements use the loader's bool parser, including one set with WithParser
+	loader := NewEnvLoader(WithParser(reflect.Bool, &strictBoolParser{}))
+	err = loader.LoadFromMap(map[string]string{"FLAGS": "true,yes"}, &BoolConfig{})
+	assert.ErrorContains(t, err, `strict bool: "yes"`)
+}
+
+// strictBoolParser only accepts true and false
+type strictBoolParser struct{}
+
+func (p *strictBoolParser) Parse(value string, field reflect.Value) error {
+	switch value {
+	case "true", "false":
+		field.SetBool(value == "true")
+		return nil
+	}
+	return fmt.Errorf("strict bool: %q", value)
+}
+
+func TestJSONFormatFields(t *testing.T) {
+	type Backend struct {
+		Host string `json:"host"`
+		Port int    `json:"port"`
+	}
+	type Limits struct {
+		Burst int `json:"burst"`
+	}
+	type JSONConfig struct {
+		Backends []Backend               `env:"JSON_BACKENDS" format:"json"`
+		Weights  map[string][]int        `env:"JSON_WEIGHTS" format:"json"`
+		Limits   Limits                  `env:"JSON_LIMITS" format:"json"`
+		Extra    *map[string]interface{} `env:"JSON_EXTRA" format:"json"`
+	}
+
+	loader := NewEnvLoader()
+
+	t.Run("valid JSON", func(t *testing.T) {
+		cfg := &JSONConfig{}
+		err := loader.LoadFromMap(map[string]string{
+			"JSON_BACKENDS": `[{"host":"10.0.0.1","port":8080},{"host":"10.0.0.2","port":8081}]`,
+			"JSON_WEIGHTS":  `{"a":[1,2],"b":[3]}`,
+			"JSON_LIMITS":   `{"burst":10}`,
+		}, cfg)
+		assert.NoError(t, err)
+		assert.Equal(t, []Backend{{"10.0.0.1", 8080}, {"10.0.0.2", 8081}}, cfg.Backends)
+		assert.Equal(t, map[string][]int{"a": {1, 2}, "b": {3}}, cfg.Weights)
+		assert.Equal(t, Limits{Burst: 10}, cfg.Limits)
+		assert.Nil(t, cfg.Extra)
+	})
+
+	t.Run("malformed JSON", func(t *testing.T) {
+		cfg := &JSONConfig{}
+		err := loader.LoadFromMap(map[string]string{"JSON_BACKENDS": `[{"host":`}, cfg)
+		assert.ErrorContains(t, err, "env JSON_BACKENDS (field Backends): invalid JSON")
+	})
+
+	t.Run("element errors carry the index", func(t *testing.T) {
+		err := loader.LoadFromMap(map[string]string{
+			"JSON_BACKENDS": `[{"host":"a","port":80},{"host":"b","port":"http"}]`,
+		}, &JSONConfig{})
+		assert.ErrorContains(t, err, "env JSON_BACKENDS (field Backends[1].Port): invalid JSON")
+
+		var fieldErr *FieldError
+		assert.ErrorAs(t, err, &fieldErr)
+		assert.Equal(t, "Backends[1].Port", fieldErr.FieldName)
+
+		err = loader.LoadFromMap(map[string]string{"JSON_BACKENDS": `[{"host":"a"},"b"]`}, &JSONConfig{})
+		assert.ErrorContains(t, err, "env JSON_BACKENDS (field Backends[1]): invalid JSON")
+	})
+}
+
+func TestJSONStructFields(t *testing.T) {
+	type TLSConfig struct {
+		Cert string `env:"CERT" json:"cert"`
+		Key  string `env:"KEY" json:"key"`
+	}
+	type JSONStructConfig struct {
+		TLS   TLSConfig  `env:"TLS_JSON" format:"json" envPrefix:"TLS_"`
+		Proxy *TLSConfig `env:"PROXY_JSON" format:"json" envPrefix:"PROXY_"`
+	}
+
+	loader := NewEnvLoader()
+
+	t.Run("JSON value", func(t *testing.T) {
+		cfg := &JSONStructConfig{}
+		err := loader.LoadFromMap(map[string]string{
+			"TLS_JSON":   `{"cert":"a.pem","key":"a.key"}`,
+			"TLS_CERT":   "ignored.pem",
+			"PROXY_JSON": `{"cert":"p.pem"}`,
+		}, cfg)
+		assert.NoError(t, err)
+		assert.Equal(t, TLSConfig{Cert: "a.pem", Key: "a.key"}, cfg.TLS)
+		assert.Equal(t, &TLSConfig{Cert: "p.pem"}, cfg.Proxy)
+	})
+
+	t.Run("falls back to nested loading", func(t *testing.T) {
+		cfg := &JSONStructConfig{}
+		err := loader.LoadFromMap(map[string]string{
+			"TLS_CERT":  "b.pem",
+			"TLS_KEY":   "b.key",
+			"PROXY_KEY": "p.key",
+		}, cfg)
+		assert.NoError(t, err)
+		assert.Equal(t, TLSConfig{Cert: "b.pem", Key: "b.key"}, cfg.TLS)
+		assert.Equal(t, &TLSConfig{Key: "p.key"}, cfg.Proxy)
+	})
+
+	t.Run("malformed JSON", func(t *testing.T) {
+		err := loader.LoadFromMap(map[string]string{"TLS_JSON": `{"cert":`}, &JSONStructConfig{})
+		assert.ErrorContains(t, err, "env TLS_JSON (field TLS): invalid JSON")
+	})
+}
+
+func TestByteSliceFields(t *testing.T) {
+	type KeyConfig struct {
+		SigningKey []byte `env:"BYTES_SIGNING_KEY" encoding:"base64"`
+		Salt       []byte `env:"BYTES_SALT" encoding:"hex"`
+		Token      []byte `env:"BYTES_TOKEN"`
+	}
+
+	loader := NewEnvLoader()
+
+	cfg := &KeyConfig{}
+	err := loader.LoadFromMap(map[string]string{
+		"BYTES_SIGNING_KEY": "AQID",
+		"BYTES_SALT":        "0a0b",
+		"BYTES_TOKEN":       "abc,def",
+	}, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{1, 2, 3}, cfg.SigningKey)
+	assert.Equal(t, []byte{0x0a, 0x0b}, cfg.Salt)
+	assert.Equal(t, []byte("abc,def"), cfg.Token)
+
+	err = loader.LoadFromMap(map[string]string{"BYTES_SIGNING_KEY": "%%%"}, &KeyConfig{})
+	assert.ErrorContains(t, err, "env BYTES_SIGNING_KEY (field SigningKey): invalid base64 value")
+}
+
+type hookDatabase struct {
+	Host string `env:"HOOK_DB_HOST"`
+	Port int    `env:"HOOK_DB_PORT"`
+	Addr string
+}
+
+func (d *hookDatabase) AfterLoad() error {
+	d.Addr = net.JoinHostPort(d.Host, strconv.Itoa(d.Port))
+	return nil
+}
+
+type hookConfig struct {
+	Name     string `env:"HOOK_NAME"`
+	Database hookDatabase
+	Label    string
+}
+
+func (c *hookConfig) AfterLoad() error {
+	if c.Name == "forbidden" {
+		return errors.New("name is not allowed")
+	}
+	// Nested hooks have already run
+	c.Label = c.Name + "@" + c.Database.Addr
+	return nil
+}
+
+type hookNestedError struct {
+	Port int `env:"HOOK_ERR_PORT"`
+}
+
+func (h hookNestedError) AfterLoad() error {
+	return fmt.Errorf("port %d rejected", h.Port)
+}
+
+func TestAfterLoad(t *testing.T) {
+	loader := NewEnvLoader()
+
+	t.Run("derives fields", func(t *testing.T) {
+		cfg := &hookConfig{}
+		err := loader.LoadFromMap(map[string]string{
+			"HOOK_NAME":    "api",
+			"HOOK_DB_HOST": "db.local",
+			"HOOK_DB_PORT": "5432",
+		}, cfg)
+		assert.NoError(t, err)
+		assert.Equal(t, "db.local:5432", cfg.Database.Addr)
+		assert.Equal(t, "api@db.local:5432", cfg.Label)
+	})
+
+	t.Run("error surfaces", func(t *testing.T) {
+		cfg := &hookConfig{}
+		err := loader.LoadFromMap(map[string]string{"HOOK_NAME": "forbidden"}, cfg)
+		assert.EqualError(t, err, "name is not allowed")
+	})
+
+	t.Run("nested error includes path", func(t *testing.T) {
+		cfg := &struct{ Server hookNestedError }{}
+		err := loader.LoadFromMap(map[string]string{"HOOK_ERR_PORT": "1"}, cfg)
+		assert.EqualError(t, err, "Server: port 1 rejected")
+	})
+
+	t.Run("skipped when loading fails", func(t *testing.T) {
+		cfg := &hookConfig{}
+		err := loader.LoadFromMap(map[string]string{"HOOK_DB_PORT": "abc"}, cfg)
+		assert.Error(t, err)
+		assert.Empty(t, cfg.Database.Addr)
+		assert.Empty(t, cfg.Label)
+	})
+}
+
+type initCache struct {
+	Size   int `env:"INIT_CACHE_SIZE"`
+	events []string
+}
+
+func (c *initCache) Init() {
+	c.Size = 64
+	c.events = append(c.events, "init")
+}
+
+type initConfig struct {
+	Host   string            `env:"INIT_HOST"`
+	Port   int               `env:"INIT_PORT"`
+	Labels map[string]string `env:"INIT_LABELS"`
+	Cache  initCache
+	events []string
+}
+
+func (c *initConfig) Init() {
+	c.Host = "localhost"
+	c.Port = 8080
+	c.Labels = map[string]string{}
+	c.events = append(c.events, "init")
+}
+
+func (c *initConfig) AfterLoad() error {
+	c.events = append(c.events, "after:"+c.Host)
+	return nil
+}
+
+type initDefaultsConfig struct {
+	Port    int    `env:"PORT" default:"8080"`
+	Host    string `env:"HOST"`
+	Zone    string `env:"ZONE"`
+	Timeout int    `env:"TIMEOUT" default:"30"`
+}
+
+func (c *initDefaultsConfig) Init() {
+	c.Port = 9000
+	c.Host = "init-host"
+	c.Zone = "init-zone"
+}
+
+func (c *initDefaultsConfig) Defaults() map[string]string {
+	return map[string]string{"ZONE": "computed-zone"}
+}
+
+func TestInit(t *testing.T) {
+	loader := NewEnvLoader()
+
+	t.Run("values from Init are kept when unset", func(t *testing.T) {
+		cfg := &initConfig{}
+		err := loader.LoadFromMap(map[string]string{}, cfg)
+		assert.NoError(t, err)
+		assert.Equal(t, "localhost", cfg.Host)
+		assert.Equal(t, 8080, cfg.Port)
+		assert.NotNil(t, cfg.Labels)
+		assert.Equal(t, 64, cfg.Cache.Size)
+		assert.Equal(t, []string{"init", "after:localhost"}, cfg.events)
+		assert.Equal(t, []string{"init"}, cfg.Cache.events)
+	})
+
+	t.Run("variables override Init", func(t *testing.T) {
+		cfg := &initConfig{}
+		err := loader.LoadFromMap(map[string]string{
+			"INIT_HOST":       "db.local",
+			"INIT_CACHE_SIZE": "128",
+		}, cfg)
+		assert.NoError(t, err)
+		assert.Equal(t, "db.local", cfg.Host)
+		assert.Equal(t, 8080, cfg.Port)
+		assert.Equal(t, 128, cfg.Cache.Size)
+		assert.Equal(t, []string{"init", "after:db.local"}, cfg.events)
+	})
+
+	t.Run("values from Init take precedence over defaults", func(t *testing.T) {
+		cfg := &initDefaultsConfig{}
+		err := NewEnvLoader(WithDefaults(map[string]string{"HOST": "option-host"})).LoadFromMap(nil, cfg)
+		assert.NoError(t, err)
+		assert.Equal(t, 9000, cfg.Port)
+		assert.Equal(t, "init-host", cfg.Host)
+		assert.Equal(t, "init-zone", cfg.Zone)
+		assert.Equal(t, 30, cfg.Timeout) // left at zero by Init
+
+		cfg = &initDefaultsConfig{}
+		err = loader.LoadFromMap(map[string]string{"PORT": "7000"}, cfg)
+		assert.NoError(t, err)
+		assert.Equal(t, 7000, cfg.Port)
+	})
+
+	t.Run("values from Init satisfy strict mode", func(t *testing.T) {
+		cfg := &initConfig{}
+		err := NewEnvLoader(WithStrictMode()).LoadFromMap(map[string]string{}, cfg)
+		assert.NoError(t, err)
+		assert.Equal(t, "localhost", cfg.Host)
+
+		err = NewEnvLoader(WithStrictMode()).LoadFromMap(map[string]string{}, &initCache{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("indexed elements are initialized", func(t *testing.T) {
+		type initSlice struct {
+			Caches []initCache `envPrefix:"CACHE_"`
+		}
+
+		cfg := &initSlice{}
+		err := loader.LoadFromMap(map[string]string{
+			"CACHE_0_INIT_CACHE_SIZE": "128",
+			"CACHE_1_INIT_CACHE_SIZE": "256",
+		}, cfg)
+		assert.NoError(t, err)
+		if assert.Len(t, cfg.Caches, 2) {
+			assert.Equal(t, 128, cfg.Caches[0].Size)
+			assert.Equal(t, []string{"init"}, cfg.Caches[0].events)
+			assert.Equal(t, []string{"init"}, cfg.Caches[1].events)
+		}
+	})
+}
+
+type defaulterConfig struct {
+	Host string `env:"HOST"`
+	Port int    `env:"PORT" default:"8080"`
+	Zone string `env:"ZONE" default:"tag-zone"`
+}
+
+func (c *defaulterConfig) Defaults() map[string]string {
+	return map[string]string{"APP_HOST": "computed-host", "APP_ZONE": "computed-zone"}
+}
+
+func TestDefaulter(t *testing.T) {
+	loader := NewEnvLoader(WithPrefix("APP_"))
+
+	t.Run("computed default used when unset", func(t *testing.T) {
+		cfg := &defaulterConfig{}
+		err := loader.LoadFromMap(map[string]string{}, cfg)
+		assert.NoError(t, err)
+		assert.Equal(t, "computed-host", cfg.Host)
+		assert.Equal(t, "computed-zone", cfg.Zone) // takes precedence over the tag
+		assert.Equal(t, 8080, cfg.Port)
+	})
+
+	t.Run("env value wins", func(t *testing.T) {
+		cfg := &defaulterConfig{}
+		err := loader.LoadFromMap(map[string]string{"APP_HOST": "env-host"}, cfg)
+		assert.NoError(t, err)
+		assert.Equal(t, "env-host", cfg.Host)
+	})
+
+	t.Run("WithDefaults wins", func(t *testing.T) {
+		cfg := &defaulterConfig{}
+		err := NewEnvLoader(
+			WithPrefix("APP_"),
+			WithDefaults(map[string]string{"APP_HOST": "override-host"}),
+		).LoadFromMap(map[string]string{}, cfg)
+		assert.NoError(t, err)
+		assert.Equal(t, "override-host", cfg.Host)
+	})
+}
+
+func TestWithTrimSpace(t *testing.T) {
+	type TrimConfig struct {
+		Port    int    `env:"TRIM_PORT"`
+		Name    string `env:"TRIM_NAME"`
+		Raw     string `env:"TRIM_RAW" trim:"false"`
+		Timeout int    `env:"TRIM_TIMEOUT" default:"30"`
+	}
+
+	values := map[string]string{
+		"TRIM_PORT":    "8080\n",
+		"TRIM_NAME":    "  my service \t",
+		"TRIM_RAW":     " padded ",
+		"TRIM_TIMEOUT": "   ",
+	}
+
+	t.Run("enabled", func(t *testing.T) {
+		cfg := &TrimConfig{}
+		err := NewEnvLoader(WithTrimSpace()).LoadFromMap(values, cfg)
+		assert.NoError(t, err)
+		assert.Equal(t, 8080, cfg.Port)
+		assert.Equal(t, "my service", cfg.Name)
+		assert.Equal(t, " padded ", cfg.Raw)
+		assert.Equal(t, 30, cfg.Timeout)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		cfg := &TrimConfig{}
+		err := NewEnvLoader().LoadFromMap(values, cfg)
+		assert.ErrorContains(t, err, "env TRIM_PORT (field Port)")
+	})
+}
+
+func TestWithDefaults(t *testing.T) {
+	type DefaultsConfig struct {
+		Host string `env:"HOST" default:"localhost"`
+		Port int    `env:"PORT" default:"8080"`
+		Mode string `env:"MODE" default:"dev"`
+	}
+
+	loader := NewEnvLoader(
+		WithPrefix("APP_"),
+		WithDefaults(map[string]string{"APP_HOST": "db.internal", "APP_PORT": "9090"}),
+	)
+
+	cfg := &DefaultsConfig{}
+	err := loader.LoadFromMap(map[string]string{"APP_PORT": "7070"}, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, "db.internal", cfg.Host) // map default wins over tag default
+	assert.Equal(t, 7070, cfg.Port)          // env value wins over both
+	assert.Equal(t, "dev", cfg.Mode)         // tag default is the final fallback
+}
+
+func TestNoEmptySliceElements(t *testing.T) {
+	type HostsConfig struct {
+		Hosts []string `env:"NO_EMPTY_HOSTS" required:"true" no_empty:"true"`
+		Tags  []string `env:"NO_EMPTY_TAGS" required:"true"`
+	}
+
+	loader := NewEnvLoader()
+
+	err := loader.LoadFromMap(map[string]string{"NO_EMPTY_HOSTS": "a,,b", "NO_EMPTY_TAGS": "x"}, &HostsConfig{})
+	assert.EqualError(t, err, "env NO_EMPTY_HOSTS (field Hosts): "+ErrEmptyElement+" at index 1")
+
+	cfg := &HostsConfig{}
+	err = loader.LoadFromMap(map[string]string{"NO_EMPTY_HOSTS": "a,b", "NO_EMPTY_TAGS": "a,,b"}, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "", "b"}, cfg.Tags)
+}
+
+type LoggingConfig struct {
+	Level  string `env:"LOG_LEVEL" required:"true"`
+	Format string `env:"LOG_FORMAT" default:"text"`
+}
+
+type metricsConfig struct {
+	Addr string `env:"METRICS_ADDR"`
+}
+
+type TracingConfig struct {
+	Endpoint string `env:"TRACING_ENDPOINT"`
+}
+
+func TestEmbeddedStructs(t *testing.T) {
+	type EmbeddedConfig struct {
+		LoggingConfig
+		metricsConfig
+		*TracingConfig
+		Port int `env:"PORT"`
+	}
+
+	loader := NewEnvLoader()
+
+	t.Run("fields load at the top level", func(t *testing.T) {
+		cfg := &EmbeddedConfig{}
+		err := loader.LoadFromMap(map[string]string{
+			"LOG_LEVEL":        "debug",
+			"METRICS_ADDR":     ":9100",
+			"TRACING_ENDPOINT": "http://collector",
+			"PORT":             "8080",
+		}, cfg)
+		assert.NoError(t, err)
+		assert.Equal(t, "debug", cfg.Level)
+		assert.Equal(t, "text", cfg.Format)
+		assert.Equal(t, ":9100", cfg.Addr)
+		assert.Equal(t, "http://collector", cfg.Endpoint)
+		assert.Equal(t, 8080, cfg.Port)
+	})
+
+	t.Run("errors use promoted field names", func(t *testing.T) {
+		err := loader.LoadFromMap(map[string]string{}, &EmbeddedConfig{})
+		assert.EqualError(t, err, "env LOG_LEVEL (field Level): "+ErrRequiredField)
+	})
+
+	t.Run("embedded prefix", func(t *testing.T) {
+		type PrefixedConfig struct {
+			LoggingConfig `envPrefix:"APP_"`
+		}
+
+		cfg := &PrefixedConfig{}
+		err := loader.LoadFromMap(map[string]string{"APP_LOG_LEVEL": "warn"}, cfg)
+		assert.NoError(t, err)
+		assert.Equal(t, "warn", cfg.Level)
+	})
+}
+
+type countingHook struct {
+	Name  string `env:"COUNTING_NAME"`
+	calls int
+}
+
+func (c *countingHook) AfterLoad() error {
+	c.calls++
+	return nil
+}
+
+func TestEmbeddedAfterLoadRunsOnce(t *testing.T) {
+	cfg := &struct{ countingHook }{}
+	err := NewEnvLoader().LoadFromMap(map[string]string{"COUNTING_NAME": "x"}, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, "x", cfg.Name)
+	assert.Equal(t, 1, cfg.calls)
+}
+
+func TestSkippedFields(t *testing.T) {
+	type Injected struct {
+		Token string `env:"SKIP_TOKEN" required:"true"`
+	}
+	type SkipConfig struct {
+		Port     int      `env:"SKIP_PORT"`
+		Computed string   `env:"-" required:"true"`
+		Client   Injected `env:"-"`
+		Derived  int      `env:"-"`
+	}
+
+	loader := NewEnvLoader(WithAutoEnvNames())
+	cfg := &SkipConfig{Derived: 7}
+	err := loader.LoadFromMap(map[string]string{
+		"SKIP_PORT":  "8080",
+		"SKIP_TOKEN": "ignored",
+		"COMPUTED":   "ignored",
+		"DERIVED":    "1",
+		"-":          "ignored",
+	}, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, 8080, cfg.Port)
+	assert.Empty(t, cfg.Computed)
+	assert.Empty(t, cfg.Client.Token)
+	assert.Equal(t, 7, cfg.Derived)
+
+	assert.NoError(t, loader.Validate(cfg))
+	assert.NotContains(t, loader.Usage(cfg), "TOKEN")
+}
+
+func TestArrayFields(t *testing.T) {
+	type ArrayConfig struct {
+		Servers [3]string `env:"ARRAY_SERVERS"`
+	}
+
+	loader := NewEnvLoader()
+
+	cfg := &ArrayConfig{}
+	err := loader.LoadFromMap(map[string]string{"ARRAY_SERVERS": "a,b,c"}, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, [3]string{"a", "b", "c"}, cfg.Servers)
+
+	err = loader.LoadFromMap(map[string]string{"ARRAY_SERVERS": "a,b"}, &ArrayConfig{})
+	assert.EqualError(t, err, "env ARRAY_SERVERS (field Servers): expected 3 array elements, got 2")
+}
+
+func TestConcurrentLoadConfig(t *testing.T) {
+	type ConcurrentDB struct {
+		Host string `env:"HOST" required:"true"`
+	}
+	type ConcurrentConfig struct {
+		Port     int           `env:"CONCURRENT_PORT" min:"1"`
+		Slug     string        `env:"CONCURRENT_SLUG" pattern:"^[a-z]+$"`
+		Timeout  time.Duration `env:"CONCURRENT_TIMEOUT" default:"5s"`
+		Database ConcurrentDB  `envPrefix:"CONCURRENT_DB_"`
+	}
+
+	os.Setenv("CONCURRENT_PORT", "8080")
+	os.Setenv("CONCURRENT_SLUG", "api")
+	os.Setenv("CONCURRENT_DB_HOST", "db.local")
+	defer os.Unsetenv("CONCURRENT_PORT")
+	defer os.Unsetenv("CONCURRENT_SLUG")
+	defer os.Unsetenv("CONCURRENT_DB_HOST")
+
+	const workers = 50
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cfg := &ConcurrentConfig{}
+			if err := LoadConfig(cfg); err != nil {
+				errs <- err
+				return
+			}
+			if cfg.Port != 8080 || cfg.Database.Host != "db.local" || cfg.Timeout != 5*time.Second {
+				errs <- fmt.Errorf("unexpected config: %+v", cfg)
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestWithStrictMode(t *testing.T) {
+	type StrictConfig struct {
+		Host    string `env:"STRICT_HOST"`
+		Port    int    `env:"STRICT_PORT" default:"8080"`
+		Debug   bool   `env:"STRICT_DEBUG" required:"false"`
+		Timeout *int   `env:"STRICT_TIMEOUT"`
+	}
+
+	t.Run("strict mode fails on unset field", func(t *testing.T) {
+		err := NewEnvLoader(WithStrictMode()).LoadFromMap(map[string]string{}, &StrictConfig{})
+		assert.EqualError(t, err, "env STRICT_HOST (field Host): "+ErrUnsetStrict)
+
+		var fe *FieldError
+		assert.True(t, errors.As(err, &fe))
+		assert.Equal(t, KindRequired, fe.Kind)
+	})
+
+	t.Run("strict mode passes when set", func(t *testing.T) {
+		cfg := &StrictConfig{}
+		err := NewEnvLoader(WithStrictMode()).LoadFromMap(map[string]string{"STRICT_HOST": "db"}, cfg)
+		assert.NoError(t, err)
+		assert.Equal(t, 8080, cfg.Port)
+		assert.False(t, cfg.Debug)
+		assert.Nil(t, cfg.Timeout)
+	})
+
+	t.Run("normal mode leaves zero value", func(t *testing.T) {
+		cfg := &StrictConfig{}
+		err := NewEnvLoader().LoadFromMap(map[string]string{}, cfg)
+		assert.NoError(t, err)
+		assert.Empty(t, cfg.Host)
+	})
+}
+
+func TestWithRequiredByDefault(t *testing.T) {
+	type ByDefaultConfig struct {
+		Host    string `env:"HOST"`
+		Port    int    `env:"PORT" default:"8080"`
+		Debug   bool   `env:"DEBUG"`
+		Retries int    `env:"RETRIES"`
+		Verbose bool   `env:"VERBOSE" required:"false"`
+		Timeout *int   `env:"TIMEOUT"`
+	}
+
+	loader := NewEnvLoader(WithRequiredByDefault(), WithErrorAggregation())
+
+	t.Run("untagged fields are required", func(t *testing.T) {
+		err := loader.LoadFromMap(map[string]string{}, &ByDefaultConfig{})
+		assert.ErrorContains(t, err, "env HOST (field Host): "+ErrRequiredField)
+		assert.ErrorContains(t, err, "env DEBUG (field Debug): "+ErrRequiredField)
+		assert.ErrorContains(t, err, "env TIMEOUT (field Timeout): "+ErrRequiredField)
+		assert.NotContains(t, err.Error(), "PORT")
+		assert.NotContains(t, err.Error(), "VERBOSE")
+
+		var fe *FieldError
+		assert.True(t, errors.As(err, &fe))
+		assert.Equal(t, KindRequired, fe.Kind)
+	})
+
+	t.Run("explicit zero values pass", func(t *testing.T) {
+		cfg := &ByDefaultConfig{}
+		err := loader.LoadFromMap(map[string]string{
+			"HOST":    "db",
+			"DEBUG":   "false",
+			"RETRIES": "0",
+			"TIMEOUT": "0",
+		}, cfg)
+		assert.NoError(t, err)
+		assert.Equal(t, 8080, cfg.Port) // the default satisfies the requirement
+		assert.False(t, cfg.Debug)
+		assert.Zero(t, cfg.Retries)
+		if assert.NotNil(t, cfg.Timeout) {
+			assert.Zero(t, *cfg.Timeout)
+		}
+	})
+
+	t.Run("validate reports zero values", func(t *testing.T) {
+		err := loader.Validate(&ByDefaultConfig{})
+		assert.ErrorContains(t, err, "env HOST (field Host): "+ErrRequiredField)
+		assert.NotContains(t, err.Error(), "PORT")
+
+		timeout := 5
+		err = loader.Validate(&ByDefaultConfig{Host: "db", Debug: true, Retries: 3, Timeout: &timeout})
+		assert.NoError(t, err)
+
+		err = NewEnvLoader().Validate(&ByDefaultConfig{})
+		assert.NoError(t, err)
+	})
+}
+
+func TestDurationDefaults(t *testing.T) {
+	type DurationConfig struct {
+		Bare     time.Duration  `env:"DURATION_BARE" default:"30"`
+		Suffixed time.Duration  `env:"DURATION_SUFFIXED" default:"1m30s"`
+		Negative time.Duration  `env:"DURATION_NEGATIVE" default:"-5"`
+		Pointer  *time.Duration `env:"DURATION_POINTER" default:"10"`
+	}
+
+	cfg := &DurationConfig{}
+	err := NewEnvLoader().LoadFromMap(map[string]string{}, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, 30*time.Second, cfg.Bare)
+	assert.Equal(t, 90*time.Second, cfg.Suffixed)
+	assert.Equal(t, -5*time.Second, cfg.Negative)
+	if assert.NotNil(t, cfg.Pointer) {
+		assert.Equal(t, 10*time.Second, *cfg.Pointer)
+	}
+
+	// Env values get the same treatment as defaults
+	cfg = &DurationConfig{}
+	err = NewEnvLoader().LoadFromMap(map[string]string{"DURATION_BARE": "45"}, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, 45*time.Second, cfg.Bare)
+}
+
+func TestPercentFields(t *testing.T) {
+	type PercentConfig struct {
+		SampleRate float64 `env:"PERCENT_SAMPLE_RATE" percent:"true"`
+		Threshold  float64 `env:"PERCENT_THRESHOLD" percent:"raw" default:"80"`
+	}
+
+	loader := NewEnvLoader()
+
+	cfg := &PercentConfig{}
+	err := loader.LoadFromMap(map[string]string{"PERCENT_SAMPLE_RATE": "50"}, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.5, cfg.SampleRate)
+	assert.Equal(t, 80.0, cfg.Threshold)
+
+	err = loader.LoadFromMap(map[string]string{"PERCENT_SAMPLE_RATE": "150"}, &PercentConfig{})
+	assert.EqualError(t, err, "env PERCENT_SAMPLE_RATE (field SampleRate): "+ErrPercentOutOfRange+": 150")
+}
+
+func TestDeprecatedNames(t *testing.T) {
+	type DeprecatedConfig struct {
+		Host string `env:"DB_HOST" deprecated:"DATABASE_HOST"`
+	}
+
+	var warnings []string
+	loader := NewEnvLoader(
+		WithPrefix("APP_"),
+		WithLogger(func(msg string) { warnings = append(warnings, msg) }),
+	)
+
+	t.Run("falls back to deprecated name", func(t *testing.T) {
+		warnings = nil
+		cfg := &DeprecatedConfig{}
+		err := loader.LoadFromMap(map[string]string{"APP_DATABASE_HOST": "old.local"}, cfg)
+		assert.NoError(t, err)
+		assert.Equal(t, "old.local", cfg.Host)
+		assert.Equal(t, []string{"config: APP_DATABASE_HOST is deprecated, use APP_DB_HOST instead"}, warnings)
+	})
+
+	t.Run("canonical name wins", func(t *testing.T) {
+		warnings = nil
+		cfg := &DeprecatedConfig{}
+		err := loader.LoadFromMap(map[string]string{
+			"APP_DB_HOST":       "new.local",
+			"APP_DATABASE_HOST": "old.local",
+		}, cfg)
+		assert.NoError(t, err)
+		assert.Equal(t, "new.local", cfg.Host)
+		assert.Empty(t, warnings)
+	})
+
+	t.Run("neither set", func(t *testing.T) {
+		warnings = nil
+		cfg := &DeprecatedConfig{}
+		assert.NoError(t, loader.LoadFromMap(map[string]string{}, cfg))
+		assert.Empty(t, cfg.Host)
+		assert.Empty(t, warnings)
+	})
+}
+
+func TestWithAliases(t *testing.T) {
+	type AliasConfig struct {
+		URL  string `env:"DATABASE_URL" default:"postgres://localhost"`
+		Port int    `env:"PORT"`
+	}
+
+	loader := NewEnvLoader(
+		WithPrefix("APP_"),
+		WithAliases(map[string]string{"PG_URL": "APP_DATABASE_URL", "POSTGRES_URL": "APP_DATABASE_URL"}),
+		WithAliases(map[string]string{"APP_HTTP_PORT": "APP_PORT"}),
+	)
+
+	tests := []struct {
+		name     string
+		values   map[string]string
+		wantURL  string
+		wantPort int
+	}{
+		{"alias fallback", map[string]string{"PG_URL": "postgres://legacy", "APP_HTTP_PORT": "8080"}, "postgres://legacy", 8080},
+		{"canonical wins", map[string]string{"APP_DATABASE_URL": "postgres://new", "PG_URL": "postgres://legacy"}, "postgres://new", 0},
+		{"aliases tried in sorted order", map[string]string{"PG_URL": "pg", "POSTGRES_URL": "postgres"}, "pg", 0},
+		{"default when neither set", map[string]string{}, "postgres://localhost", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &AliasConfig{}
+			err := loader.LoadFromMap(tt.values, cfg)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantURL, cfg.URL)
+			assert.Equal(t, tt.wantPort, cfg.Port)
+		})
+	}
+}
+
+func TestCandidateEnvNames(t *testing.T) {
+	type CandidateConfig struct {
+		URL string `env:"DATABASE_URL, DB_URL, POSTGRES_URL" default:"postgres://localhost"`
+	}
+
+	tests := []struct {
+		name   string
+		values map[string]string
+		want   string
+	}{
+		{"first name", map[string]string{"APP_DATABASE_URL": "first", "APP_POSTGRES_URL": "third"}, "first"},
+		{"third name", map[string]string{"APP_POSTGRES_URL": "third"}, "third"},
+		{"empty names skipped", map[string]string{"APP_DATABASE_URL": "", "APP_DB_URL": "second"}, "second"},
+		{"default when none set", map[string]string{}, "postgres://localhost"},
+	}
+
+	loader := NewEnvLoader(WithPrefix("APP_"))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &CandidateConfig{}
+			err := loader.LoadFromMap(tt.values, cfg)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, cfg.URL)
+		})
+	}
+
+	// Errors and usage output name the first candidate
+	err := loader.LoadFromMap(map[string]string{"APP_DB_URL": "x"}, &struct {
+		URL int `env:"DATABASE_URL,DB_URL"`
+	}{})
+	assert.ErrorContains(t, err, "env APP_DATABASE_URL (field URL)")
+}
+
+func TestWithTagName(t *testing.T) {
+	type TagNameConfig struct {
+		DatabaseURL string `json:"database_url"`
+		MaxConns    int    `json:"maxConns,omitempty"`
+		Region      string `json:"region" env:"AWS_REGION"`
+		Internal    string `json:"-"`
+		Untagged    string
+	}
+
+	values := map[string]string{
+		"DATABASE_URL": "postgres://db",
+		"MAX_CONNS":    "10",
+		"AWS_REGION":   "eu-west-1",
+		"REGION":       "ignored",
+		"INTERNAL":     "ignored",
+		"UNTAGGED":     "ignored",
+	}
+
+	t.Run("json tag fallback", func(t *testing.T) {
+		cfg := &TagNameConfig{}
+		err := NewEnvLoader(WithTagName("json")).LoadFromMap(values, cfg)
+		assert.NoError(t, err)
+		assert.Equal(t, "postgres://db", cfg.DatabaseURL)
+		assert.Equal(t, 10, cfg.MaxConns)
+		assert.Equal(t, "eu-west-1", cfg.Region) // env tag wins
+		assert.Empty(t, cfg.Internal)
+		assert.Empty(t, cfg.Untagged)
+	})
+
+	t.Run("combined with auto names", func(t *testing.T) {
+		cfg := &TagNameConfig{}
+		err := NewEnvLoader(WithTagName("json"), WithAutoEnvNames()).LoadFromMap(values, cfg)
+		assert.NoError(t, err)
+		assert.Equal(t, "ignored", cfg.Untagged)
+	})
+}
+
+func TestUnexportedFields(t *testing.T) {
+	t.Run("tagged unexported field errors", func(t *testing.T) {
+		type TaggedConfig struct {
+			Port  int    `env:"UNEXPORTED_PORT"`
+			token string `env:"UNEXPORTED_TOKEN"`
+		}
+
+		cfg := &TaggedConfig{}
+		err := NewEnvLoader().LoadFromMap(map[string]string{"UNEXPORTED_PORT": "80", "UNEXPORTED_TOKEN": "x"}, cfg)
+		assert.EqualError(t, err, "env UNEXPORTED_TOKEN (field token): "+ErrUnexportedField)
+		assert.Empty(t, cfg.token)
+
+		assert.NotPanics(t, func() { NewEnvLoader().Dump(cfg) })
+	})
+
+	t.Run("untagged unexported field ignored", func(t *testing.T) {
+		type UntaggedConfig struct {
+			Port  int `env:"UNEXPORTED_PORT"`
+			cache map[string]string
+		}
+
+		cfg := &UntaggedConfig{}
+		err := NewEnvLoader(WithAutoEnvNames()).LoadFromMap(map[string]string{"UNEXPORTED_PORT": "80", "CACHE": "a=b"}, cfg)
+		assert.NoError(t, err)
+		assert.Equal(t, 80, cfg.Port)
+		assert.Nil(t, cfg.cache)
+	})
+
+	t.Run("untagged unexported nested struct ignored", func(t *testing.T) {
+		type Inner struct {
+			Host string `env:"UNEXPORTED_HOST"`
+		}
+		type NestedConfig struct {
+			Port  int `env:"UNEXPORTED_PORT"`
+			inner Inner
+			ptr   *Inner
+		}
+
+		cfg := &NestedConfig{}
+		err := NewEnvLoader().LoadFromMap(map[string]string{"UNEXPORTED_PORT": "80", "UNEXPORTED_HOST": "db"}, cfg)
+		assert.NoError(t, err)
+		assert.Equal(t, 80, cfg.Port)
+		assert.Empty(t, cfg.inner.Host)
+		assert.Nil(t, cfg.ptr)
+		assert.Len(t, NewEnvLoader().Describe(cfg), 1)
+	})
+}
@@ -2,23 +2,115 @@ package config
 
 // Tag keys used for configuration
 const (
-	EnvTag      = "env"
-	RequiredTag = "required"
-	DefaultTag  = "default"
-	MinTag      = "min"
-	MaxTag      = "max"
-	RangeErrTag = "range_error"
+	EnvTag            = "env"
+	RequiredTag       = "required"
+	DefaultTag        = "default"
+	MinTag            = "min"
+	MaxTag            = "max"
+	RangeErrTag       = "range_error"
+	PatternTag        = "pattern"
+	PatternErrTag     = "pattern_error"
+	OneOfTag          = "oneof"
+	OneOfCITag        = "oneof_ci"
+	MinLenTag         = "minlen"
+	MaxLenTag         = "maxlen"
+	EnvPrefixTag      = "envPrefix"
+	DescTag           = "desc"
+	SecretTag         = "secret"
+	RequireSchemeTag  = "require_scheme"
+	LayoutTag         = "layout"
+	TimezoneTag       = "timezone"
+	RequiredIfTag     = "required_if"
+	RequiredErrTag    = "required_error"
+	MultipleOfTag     = "multiple_of"
+	GtTag             = "gt"
+	LtTag             = "lt"
+	GteTag            = "gte"
+	LteTag            = "lte"
+	FormatTag         = "format"
+	EncodingTag       = "encoding"
+	TrimTag           = "trim"
+	NoEmptyTag        = "no_empty"
+	PercentTag        = "percent"
+	DeprecatedTag     = "deprecated"
+	NotBlankTag       = "notblank"
+	RangeAsLengthTag  = "range_as_length"
+	BaseTag           = "base"
+	UnitTag           = "unit"
+	SizeTag           = "size"
+	GroupTag          = "group"
+	GroupRequiredTag  = "group_required"
+	GroupMaxTag       = "group_max"
+	CSVTag            = "csv"
+	TransformTag      = "transform"
+	DedupTag          = "dedup"
+	AfterTag          = "after"
+	BeforeTag         = "before"
+	CaseTag           = "case"
+	StrictBoolTag     = "strict_bool"
+	ValueDelimiterTag = "value_delimiter"
 )
 
 // Common tag values
 const (
-	TagTrue = "true"
+	TagTrue        = "true"
+	TagFalse       = "false"
+	TagSkip        = "-"
+	PercentRaw     = "raw"
+	BaseAuto       = "auto"
+	FormatJSON     = "json"
+	FormatEmail    = "email"
+	FormatHostname = "hostname"
+	FormatURI      = "uri"
+	FormatClock    = "clock"
+	FormatKV       = "kv"
+	CaseLower      = "lower"
+	CaseUpper      = "upper"
+
+	EncodingBase64 = "base64"
+	EncodingHex    = "hex"
+	EncodingString = "string"
 )
 
 // Error messages
 const (
-	ErrRequiredField   = "required field is empty"
-	ErrOutOfRange      = "value out of range"
-	ErrUnsupportedType = "unsupported type: %v"
-	ErrConfigNotPtr    = "config must be a pointer"
+	ErrRequiredField      = "required field is empty"
+	ErrOutOfRange         = "value out of range"
+	ErrUnsupportedType    = "unsupported type: %v"
+	ErrConfigNotPtr       = "config must be a pointer"
+	ErrConfigNilPtr       = "config pointer is nil"
+	ErrConfigNotStruct    = "config must point to a struct"
+	ErrValidateNotStruct  = "config must be a struct or a pointer to a struct"
+	ErrPatternMismatch    = "value does not match pattern"
+	ErrInvalidPattern     = "invalid pattern"
+	ErrNotOneOf           = "value is not one of the allowed values"
+	ErrLengthOutOfRange   = "value length out of range"
+	ErrMissingScheme      = "URL scheme is required"
+	ErrNotMultiple        = "value is not a multiple of the step"
+	ErrEmptyElement       = "list contains an empty element"
+	ErrUnsetStrict        = "value is not set and has no default"
+	ErrPercentOutOfRange  = "percentage must be between 0 and 100"
+	ErrBlankValue         = "value is blank"
+	ErrRedactedValue      = "invalid value (redacted)"
+	ErrUnexportedField    = "cannot set unexported field"
+	ErrDefaultCycle       = "default references form a cycle"
+	ErrFormatMismatch     = "value does not match format"
+	ErrUnknownFormat      = "unknown format"
+	ErrUnknownVariables   = "unknown variables"
+	ErrDuplicateKeys      = "env name used by more than one field"
+	ErrDanglingEscape     = "list ends with an unescaped backslash"
+	ErrUnknownUnit        = "unknown duration unit"
+	ErrDigitSeparator     = "underscore must separate digits"
+	ErrGroupTooFew        = "too few fields in group are set"
+	ErrGroupTooMany       = "too many fields in group are set"
+	ErrUnknownTransform   = "unknown transform"
+	ErrUnknownCase        = "unknown case"
+	ErrStrictBool         = "expected true or false"
+	ErrRequiredBlock      = "required struct has no fields set"
+	ErrDedupNotComparable = "dedup requires comparable elements"
+	ErrTimeNotAfter       = "time must be after"
+	ErrTimeNotBefore      = "time must be before"
+	ErrKVPair             = "expected key=value"
+	ErrUnknownKVKey       = "unknown key"
+	ErrKeyFuncIndexed     = "indexed struct slices cannot be loaded with a key func"
 )
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"reflect"
+)
+
+// FieldInfo describes an env-backed field of a config struct
+type FieldInfo struct {
+	Path        string       // dotted Go field path, e.g. Database.Host
+	EnvKey      string       // full env name including prefixes
+	Type        reflect.Type // Go type of the field
+	Required    bool
+	Default     string
+	Min         string
+	Max         string
+	Description string // from the desc tag
+	Secret      bool
+}
+
+// Describe returns metadata for every env-backed field of cfg using the default loader
+func Describe(cfg interface{}) []FieldInfo {
+	return defaultLoader.Describe(cfg)
+}
+
+// Describe returns metadata for every env-backed field of cfg in declaration
+// order, recursing into nested structs. It is meant for tooling such as doc or
+// sample .env generators; cfg may be a nil pointer.
+func (l *EnvLoader) Describe(cfg interface{}) []FieldInfo {
+	v, ok := configStruct(cfg)
+	if !ok {
+		return nil
+	}
+
+	var fields []FieldInfo
+	_ = l.walkFields(v, l.prefix, "", func(f fieldRef) error {
+		tags := f.Field.Tag
+		fields = append(fields, FieldInfo{
+			Path:        f.Path,
+			EnvKey:      f.EnvKey,
+			Type:        f.Field.Type,
+			Required:    l.isRequiredTag(tags),
+			Default:     l.defaultValue(f.EnvKey, f.Field, nil),
+			Min:         tagOr(tags, MinTag, GteTag),
+			Max:         tagOr(tags, MaxTag, LteTag),
+			Description: tags.Get(DescTag),
+			Secret:      tags.Get(SecretTag) == TagTrue,
+		})
+		return nil
+	})
+
+	return fields
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribe(t *testing.T) {
+	type PoolConfig struct {
+		Size int `env:"SIZE" min:"1" max:"100" default:"10"`
+	}
+	type DatabaseConfig struct {
+		Host string     `env:"HOST" required:"true" desc:"database host"`
+		Pool PoolConfig `envPrefix:"POOL_"`
+	}
+	type DescribeConfig struct {
+		Timeout  time.Duration  `env:"TIMEOUT" default:"5s"`
+		Database DatabaseConfig `envPrefix:"DB_"`
+		Ignored  string
+	}
+
+	fields := NewEnvLoader(WithPrefix("APP_")).Describe((*DescribeConfig)(nil))
+	assert.Equal(t, []FieldInfo{
+		{
+			Path:    "Timeout",
+			EnvKey:  "APP_TIMEOUT",
+			Type:    reflect.TypeOf(time.Duration(0)),
+			Default: "5s",
+		},
+		{
+			Path:        "Database.Host",
+			EnvKey:      "APP_DB_HOST",
+			Type:        reflect.TypeOf(""),
+			Required:    true,
+			Description: "database host",
+		},
+		{
+			Path:    "Database.Pool.Size",
+			EnvKey:  "APP_DB_POOL_SIZE",
+			Type:    reflect.TypeOf(0),
+			Default: "10",
+			Min:     "1",
+			Max:     "100",
+		},
+	}, fields)
+
+	assert.Nil(t, Describe(42))
+}
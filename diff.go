@@ -0,0 +1,87 @@
+package config
+
+import (
+	"reflect"
+)
+
+// FieldDiff describes a field whose value differs between two configs
+type FieldDiff struct {
+	Path   string // dotted Go field path, e.g. Database.Host
+	EnvKey string // full env name including prefixes
+	Old    string // previous value, masked for secret fields
+	New    string // current value, masked for secret fields
+	Secret bool
+}
+
+// Diff returns the fields that differ between old and new using the default loader
+func Diff(old, new interface{}) []FieldDiff {
+	return defaultLoader.Diff(old, new)
+}
+
+// Diff returns the env-backed fields whose values differ between old and new,
+// in declaration order, recursing into nested structs. Both must be the same
+// struct type (or pointers to it); otherwise Diff returns nil. Values are
+// rendered as by Dump, so fields tagged secret:"true" report a change without
+// exposing either value.
+func (l *EnvLoader) Diff(old, new interface{}) []FieldDiff {
+	oldV, ok := configStruct(old)
+	if !ok {
+		return nil
+	}
+	newV, ok := configStruct(new)
+	if !ok || oldV.Type() != newV.Type() {
+		return nil
+	}
+
+	oldFields := make(map[string]fieldRef)
+	_ = l.walkFields(oldV, l.prefix, "", func(f fieldRef) error {
+		if !f.Placeholder {
+			oldFields[f.Path] = f
+		}
+		return nil
+	})
+
+	var diffs []FieldDiff
+	_ = l.walkFields(newV, l.prefix, "", func(f fieldRef) error {
+		if f.Placeholder {
+			return nil
+		}
+		prev, found := oldFields[f.Path]
+		delete(oldFields, f.Path)
+		if !f.Value.CanInterface() {
+			return nil
+		}
+		if found && reflect.DeepEqual(prev.Value.Interface(), f.Value.Interface()) {
+			return nil
+		}
+		diffs = append(diffs, fieldDiff(f.Path, f.EnvKey, f.Field, prev.Value, f.Value))
+		return nil
+	})
+
+	// Fields only present in old, such as removed elements of a struct slice
+	_ = l.walkFields(oldV, l.prefix, "", func(f fieldRef) error {
+		if _, removed := oldFields[f.Path]; removed && f.Value.CanInterface() {
+			diffs = append(diffs, fieldDiff(f.Path, f.EnvKey, f.Field, f.Value, reflect.Value{}))
+		}
+		return nil
+	})
+
+	return diffs
+}
+
+// fieldDiff renders a change, masking secret values. An invalid value stands
+// for a field missing on that side.
+func fieldDiff(path, envKey string, field reflect.StructField, old, new reflect.Value) FieldDiff {
+	d := FieldDiff{Path: path, EnvKey: envKey, Secret: field.Tag.Get(SecretTag) == TagTrue}
+	if d.Secret {
+		d.Old, d.New = maskedValue, maskedValue
+		return d
+	}
+	if old.IsValid() {
+		d.Old = formatValue(old)
+	}
+	if new.IsValid() {
+		d.New = formatValue(new)
+	}
+	return d
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type diffDatabase struct {
+	Host     string `env:"HOST"`
+	Password string `env:"PASSWORD" secret:"true"`
+}
+
+type diffConfig struct {
+	Port     int          `env:"PORT"`
+	Hosts    []string     `env:"HOSTS"`
+	Database diffDatabase `envPrefix:"DB_"`
+}
+
+func TestDiff(t *testing.T) {
+	base := diffConfig{
+		Port:     8080,
+		Hosts:    []string{"a", "b"},
+		Database: diffDatabase{Host: "db", Password: "old-secret"},
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*diffConfig)
+		want   []FieldDiff
+	}{
+		{
+			name:   "unchanged",
+			modify: func(*diffConfig) {},
+			want:   nil,
+		},
+		{
+			name:   "changed scalar",
+			modify: func(c *diffConfig) { c.Port = 9090 },
+			want:   []FieldDiff{{Path: "Port", EnvKey: "PORT", Old: "8080", New: "9090"}},
+		},
+		{
+			name:   "changed nested field",
+			modify: func(c *diffConfig) { c.Database.Host = "replica"; c.Hosts = []string{"a"} },
+			want: []FieldDiff{
+				{Path: "Hosts", EnvKey: "HOSTS", Old: "a,b", New: "a"},
+				{Path: "Database.Host", EnvKey: "DB_HOST", Old: "db", New: "replica"},
+			},
+		},
+		{
+			name:   "changed secret",
+			modify: func(c *diffConfig) { c.Database.Password = "new-secret" },
+			want: []FieldDiff{
+				{Path: "Database.Password", EnvKey: "DB_PASSWORD", Old: maskedValue, New: maskedValue, Secret: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated := base
+			updated.Hosts = append([]string(nil), base.Hosts...)
+			tt.modify(&updated)
+			assert.Equal(t, tt.want, Diff(&base, &updated))
+		})
+	}
+}
+
+func TestDiff_StructSlices(t *testing.T) {
+	type Backend struct {
+		URL string `env:"URL"`
+	}
+	type SliceConfig struct {
+		Backends []Backend `envPrefix:"BACKEND_"`
+	}
+
+	old := &SliceConfig{Backends: []Backend{{URL: "a"}, {URL: "b"}}}
+	updated := &SliceConfig{Backends: []Backend{{URL: "a"}}}
+
+	assert.Equal(t, []FieldDiff{
+		{Path: "Backends[1].URL", EnvKey: "BACKEND_1_URL", Old: "b"},
+	}, Diff(old, updated))
+}
+
+func TestDiff_MismatchedTypes(t *testing.T) {
+	assert.Nil(t, Diff(&diffConfig{}, &diffDatabase{}))
+	assert.Nil(t, Diff(42, &diffConfig{}))
+}
@@ -0,0 +1,133 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// LoadFromFile loads configuration from a .env file. Values from the configured
+// sources (the process environment by default) take precedence over values from
+// the file, and the process environment itself is never modified.
+func (l *EnvLoader) LoadFromFile(path string, cfg interface{}) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	values, err := parseDotEnv(f)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return l.loadDotEnv(values, cfg)
+}
+
+// LoadFromReader loads configuration from .env formatted lines read from r, for
+// example a secret mounted as a stream. Precedence is the same as for LoadFromFile.
+func (l *EnvLoader) LoadFromReader(r io.Reader, cfg interface{}) error {
+	values, err := parseDotEnv(r)
+	if err != nil {
+		return err
+	}
+	return l.loadDotEnv(values, cfg)
+}
+
+// loadDotEnv loads cfg from the configured sources, falling back to values
+func (l *EnvLoader) loadDotEnv(values map[string]string, cfg interface{}) error {
+	sources := append(l.callSources(), dotEnvSource{MapSource(values)})
+	return l.load(cfg, &loadState{sources: sources})
+}
+
+// dotEnvSource holds the values read from a .env file or reader
+type dotEnvSource struct {
+	MapSource
+}
+
+// parseDotEnv reads KEY=VALUE lines, skipping blank lines and # comments.
+// CRLF line endings and a leading UTF-8 byte order mark are accepted.
+func parseDotEnv(r io.Reader) (map[string]string, error) {
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		text := scanner.Text()
+		if lineNum == 1 {
+			text = strings.TrimPrefix(text, "\ufeff")
+		}
+		line := strings.TrimSpace(text)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", lineNum)
+		}
+
+		value, err := parseDotEnvValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+		values[key] = value
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// parseDotEnvValue unquotes a raw value. Double-quoted values support backslash
+// escapes, single-quoted values are literal, and unquoted values may carry a
+// trailing " # comment".
+func parseDotEnvValue(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+
+	switch value[0] {
+	case '\'':
+		end := strings.IndexByte(value[1:], '\'')
+		if end < 0 {
+			return "", fmt.Errorf("unterminated quoted value")
+		}
+		return value[1 : end+1], checkDotEnvTrailing(value[end+2:])
+	case '"':
+		var b strings.Builder
+		for i := 1; i < len(value); i++ {
+			switch c := value[i]; {
+			case c == '\\' && i+1 < len(value):
+				i++
+				if value[i] == 'n' {
+					b.WriteByte('\n')
+				} else {
+					b.WriteByte(value[i])
+				}
+			case c == '"':
+				return b.String(), checkDotEnvTrailing(value[i+1:])
+			default:
+				b.WriteByte(c)
+			}
+		}
+		return "", fmt.Errorf("unterminated quoted value")
+	}
+
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = strings.TrimSpace(value[:i])
+	}
+	return value, nil
+}
+
+// checkDotEnvTrailing ensures only whitespace or a comment follows a quoted value
+func checkDotEnvTrailing(rest string) error {
+	rest = strings.TrimSpace(rest)
+	if rest != "" && !strings.HasPrefix(rest, "#") {
+		return fmt.Errorf("unexpected characters after quoted value: %q", rest)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeEnvFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), ".env")
+	err := os.WriteFile(path, []byte(content), 0o600)
+	assert.NoError(t, err)
+	return path
+}
+
+func TestLoadFromFile(t *testing.T) {
+	type FileConfig struct {
+		Name    string `env:"FILE_NAME"`
+		Message string `env:"FILE_MESSAGE"`
+		Literal string `env:"FILE_LITERAL"`
+		Port    int    `env:"FILE_PORT" required:"true"`
+		Host    string `env:"FILE_HOST" default:"localhost"`
+	}
+
+	path := writeEnvFile(t, `
+# Local development settings
+FILE_NAME=app # inline comment
+
+FILE_MESSAGE="hello world"
+export FILE_LITERAL='single $quoted'
+FILE_PORT=8080
+`)
+
+	os.Unsetenv("FILE_NAME")
+	os.Unsetenv("FILE_MESSAGE")
+	os.Unsetenv("FILE_LITERAL")
+	os.Unsetenv("FILE_PORT")
+	os.Unsetenv("FILE_HOST")
+
+	cfg := &FileConfig{}
+	err := NewEnvLoader().LoadFromFile(path, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, "app", cfg.Name)
+	assert.Equal(t, "hello world", cfg.Message)
+	assert.Equal(t, "single $quoted", cfg.Literal)
+	assert.Equal(t, 8080, cfg.Port)
+	assert.Equal(t, "localhost", cfg.Host)
+
+	// The process environment must not be modified
+	_, ok := os.LookupEnv("FILE_PORT")
+	assert.False(t, ok)
+}
+
+func TestLoadFromFilePrecedence(t *testing.T) {
+	type FileConfig struct {
+		Port int `env:"FILE_PRECEDENCE_PORT"`
+	}
+
+	path := writeEnvFile(t, "FILE_PRECEDENCE_PORT=8080\n")
+
+	os.Setenv("FILE_PRECEDENCE_PORT", "9090")
+	defer os.Unsetenv("FILE_PRECEDENCE_PORT")
+
+	cfg := &FileConfig{}
+	err := NewEnvLoader().LoadFromFile(path, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, 9090, cfg.Port)
+}
+
+func TestLoadFromFileErrors(t *testing.T) {
+	type FileConfig struct {
+		Name string `env:"FILE_NAME"`
+	}
+
+	err := NewEnvLoader().LoadFromFile(filepath.Join(t.TempDir(), "missing.env"), &FileConfig{})
+	assert.Error(t, err)
+
+	path := writeEnvFile(t, "FILE_NAME=\"unterminated\n")
+	err = NewEnvLoader().LoadFromFile(path, &FileConfig{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "line 1")
+}
+
+func TestLoadFromReader(t *testing.T) {
+	type ReaderConfig struct {
+		Name    string `env:"READER_NAME"`
+		Message string `env:"READER_MESSAGE"`
+		Port    int    `env:"READER_PORT"`
+	}
+
+	os.Unsetenv("READER_NAME")
+	os.Unsetenv("READER_MESSAGE")
+	os.Unsetenv("READER_PORT")
+
+	input := "\ufeffREADER_NAME=app\r\n# comment\r\nREADER_MESSAGE=\"hello world\"\r\n\r\nREADER_PORT=8080\r\n"
+
+	cfg := &ReaderConfig{}
+	err := NewEnvLoader().LoadFromReader(strings.NewReader(input), cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, "app", cfg.Name) // the BOM is not part of the key
+	assert.Equal(t, "hello world", cfg.Message)
+	assert.Equal(t, 8080, cfg.Port)
+
+	err = NewEnvLoader().LoadFromReader(strings.NewReader("READER_NAME\r\n"), &ReaderConfig{})
+	assert.ErrorContains(t, err, "line 1")
+}
+
+func TestParseDotEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    map[string]string
+		wantErr bool
+	}{
+		{"simple", "A=1\nB=two", map[string]string{"A": "1", "B": "two"}, false},
+		{"comments and blanks", "# comment\n\nA=1\n  # indented comment", map[string]string{"A": "1"}, false},
+		{"double quoted with spaces", `A="a b c"`, map[string]string{"A": "a b c"}, false},
+		{"double quoted escapes", `A="line\nnext \"quoted\""`, map[string]string{"A": "line\nnext \"quoted\""}, false},
+		{"double quoted hash", `A="a # b" # comment`, map[string]string{"A": "a # b"}, false},
+		{"single quoted literal", `A='a\nb'`, map[string]string{"A": `a\nb`}, false},
+		{"empty value", "A=", map[string]string{"A": ""}, false},
+		{"value with equals", "A=b=c", map[string]string{"A": "b=c"}, false},
+		{"missing equals", "A", nil, true},
+		{"missing key", "=value", nil, true},
+		{"unterminated quote", `A="abc`, nil, true},
+		{"trailing garbage", `A="abc" def`, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDotEnv(strings.NewReader(tt.input))
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
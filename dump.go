@@ -0,0 +1,66 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// maskedValue replaces the value of secret fields in dumps
+const maskedValue = "****"
+
+// Dump returns the resolved configuration keyed by env name. Nested structs are
+// flattened with their prefixes and fields tagged secret:"true" are masked.
+func (l *EnvLoader) Dump(cfg interface{}) map[string]string {
+	v, ok := configStruct(cfg)
+	if !ok {
+		return nil
+	}
+
+	values := make(map[string]string)
+	_ = l.walkFields(v, l.prefix, "", func(f fieldRef) error {
+		if f.Placeholder {
+			return nil
+		}
+		if f.Field.Tag.Get(SecretTag) == TagTrue {
+			values[f.EnvKey] = maskedValue
+		} else {
+			values[f.EnvKey] = formatValue(f.Value)
+		}
+		return nil
+	})
+
+	return values
+}
+
+// formatValue renders a field value the way it would be written in the environment
+func formatValue(v reflect.Value) string {
+	v, ok := indirect(v)
+	if !ok {
+		return ""
+	}
+
+	if _, isStringer := v.Interface().(fmt.Stringer); isStringer {
+		return fmt.Sprint(v.Interface())
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		parts := make([]string, v.Len())
+		for i := range parts {
+			parts[i] = formatValue(v.Index(i))
+		}
+		return strings.Join(parts, ",")
+	case reflect.Map:
+		parts := make([]string, 0, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			parts = append(parts, formatValue(iter.Key())+"="+formatValue(iter.Value()))
+		}
+		sort.Strings(parts)
+		return strings.Join(parts, ",")
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDump(t *testing.T) {
+	type DatabaseConfig struct {
+		Host     string `env:"HOST"`
+		Password string `env:"PASSWORD" secret:"true"`
+	}
+
+	type DumpConfig struct {
+		Port     int            `env:"PORT"`
+		APIKey   string         `env:"API_KEY" secret:"true"`
+		Token    string         `env:"TOKEN" secret:"true"`
+		Database DatabaseConfig `envPrefix:"DB_"`
+		Ignored  string
+	}
+
+	cfg := &DumpConfig{
+		Port:   8080,
+		APIKey: "super-secret",
+		Database: DatabaseConfig{
+			Host:     "db.local",
+			Password: "hunter2",
+		},
+	}
+
+	dump := NewEnvLoader(WithPrefix("APP_")).Dump(cfg)
+	assert.Equal(t, map[string]string{
+		"APP_PORT":        "8080",
+		"APP_API_KEY":     maskedValue,
+		"APP_TOKEN":       maskedValue, // masked even when empty
+		"APP_DB_HOST":     "db.local",
+		"APP_DB_PASSWORD": maskedValue,
+	}, dump)
+
+	assert.Nil(t, NewEnvLoader().Dump("not a struct"))
+}
+
+func Test_formatValue(t *testing.T) {
+	port := 8080
+
+	tests := []struct {
+		name  string
+		value interface{}
+		want  string
+	}{
+		{"string", "text", "text"},
+		{"int", 42, "42"},
+		{"bool", true, "true"},
+		{"duration", 90 * time.Second, "1m30s"},
+		{"slice", []string{"a", "b"}, "a,b"},
+		{"map", map[string]int{"b": 2, "a": 1}, "a=1,b=2"},
+		{"pointer", &port, "8080"},
+		{"nil pointer", (*int)(nil), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, formatValue(reflect.ValueOf(tt.value)))
+		})
+	}
+}
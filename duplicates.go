@@ -0,0 +1,44 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// WithRejectDuplicateKeys makes loading fail before anything is read when two
+// fields resolve to the same env name after prefixing, which usually means a
+// copy-pasted tag.
+func WithRejectDuplicateKeys() Option {
+	return func(l *EnvLoader) {
+		l.rejectDuplicates = true
+	}
+}
+
+// checkDuplicates returns an error naming every env key claimed by more than one field of v
+func (l *EnvLoader) checkDuplicates(v reflect.Value) error {
+	if !l.rejectDuplicates {
+		return nil
+	}
+
+	owners := make(map[string][]string)
+	var order []string
+	_ = l.walkFields(v, l.prefix, "", func(f fieldRef) error {
+		if _, ok := owners[f.EnvKey]; !ok {
+			order = append(order, f.EnvKey)
+		}
+		owners[f.EnvKey] = append(owners[f.EnvKey], f.Path)
+		return nil
+	})
+
+	var dups []string
+	for _, key := range order {
+		if paths := owners[key]; len(paths) > 1 {
+			dups = append(dups, fmt.Sprintf("%s (%s)", key, strings.Join(paths, ", ")))
+		}
+	}
+	if len(dups) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s: %s", ErrDuplicateKeys, strings.Join(dups, "; "))
+}
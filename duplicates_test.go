@@ -0,0 +1,64 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithRejectDuplicateKeys(t *testing.T) {
+	type UniqueConfig struct {
+		Port int `env:"PORT"`
+		DB   struct {
+			Port int `env:"PORT"`
+		} `envPrefix:"DB_"`
+	}
+	type DuplicateConfig struct {
+		Port      int    `env:"PORT"`
+		AdminPort int    `env:"PORT"`
+		Host      string `env:"HOST"`
+		DB        struct {
+			Host string `env:"HOST"`
+		} `envPrefix:""`
+	}
+
+	tests := []struct {
+		name    string
+		cfg     interface{}
+		opts    []Option
+		wantErr string
+	}{
+		{
+			name: "unique keys",
+			cfg:  &UniqueConfig{},
+			opts: []Option{WithRejectDuplicateKeys()},
+		},
+		{
+			name:    "duplicate keys",
+			cfg:     &DuplicateConfig{},
+			opts:    []Option{WithRejectDuplicateKeys()},
+			wantErr: "env name used by more than one field: PORT (Port, AdminPort); HOST (Host, DB.Host)",
+		},
+		{
+			name:    "duplicates after prefixing",
+			cfg:     &DuplicateConfig{},
+			opts:    []Option{WithPrefix("APP_"), WithRejectDuplicateKeys()},
+			wantErr: "APP_PORT (Port, AdminPort)",
+		},
+		{
+			name: "disabled by default",
+			cfg:  &DuplicateConfig{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewEnvLoader(tt.opts...).LoadFromMap(map[string]string{"PORT": "8080", "APP_PORT": "8080"}, tt.cfg)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ErrorKind classifies why a field failed to load
+type ErrorKind int
+
+const (
+	// KindParse means the raw value could not be converted to the field type
+	KindParse ErrorKind = iota
+	// KindValidation means the parsed value was rejected by a validator
+	KindValidation
+	// KindRequired means a required value was missing
+	KindRequired
+)
+
+// String returns the name of the error kind
+func (k ErrorKind) String() string {
+	switch k {
+	case KindParse:
+		return "parse"
+	case KindValidation:
+		return "validation"
+	case KindRequired:
+		return "required"
+	default:
+		return fmt.Sprintf("ErrorKind(%d)", int(k))
+	}
+}
+
+// FieldError describes a failure to load or validate a single field.
+// Use errors.As to extract it from the error returned by the loader.
+type FieldError struct {
+	FieldName string // dotted Go field path, e.g. Database.Host
+	EnvKey    string // full env name including prefixes
+	Kind      ErrorKind
+	Err       error
+}
+
+// Error formats the error as "env KEY (field Path): cause"
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("env %s (field %s): %v", e.EnvKey, e.FieldName, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// fieldError wraps err with the env name and field path it relates to
+func fieldError(envKey, path string, kind ErrorKind, err error) error {
+	return &FieldError{FieldName: path, EnvKey: envKey, Kind: kind, Err: err}
+}
+
+// unsupportedError reports a field type that no parser can handle
+type unsupportedError struct {
+	msg string
+}
+
+func (e *unsupportedError) Error() string {
+	return e.msg
+}
+
+// elementError locates a parse error inside an element of a field, e.g. [1].Port
+type elementError struct {
+	path string
+	err  error
+}
+
+func (e *elementError) Error() string {
+	return e.path + ": " + e.err.Error()
+}
+
+func (e *elementError) Unwrap() error {
+	return e.err
+}
+
+// isUnsupported reports whether err was caused by an unsupported field type
+func isUnsupported(err error) bool {
+	var ue *unsupportedError
+	return errors.As(err, &ue)
+}
+
+// redactParseError replaces a parse error for a secret field, which may quote the
+// raw value, with a generic message. The strconv cause is kept for errors.Is.
+func redactParseError(err error) error {
+	var numErr *strconv.NumError
+	if errors.As(err, &numErr) {
+		return fmt.Errorf("%s: %w", ErrRedactedValue, numErr.Err)
+	}
+	return errors.New(ErrRedactedValue)
+}
+
+// validationKind returns KindRequired for missing required values and
+// KindValidation for any other validator error
+func validationKind(err error) ErrorKind {
+	var re *requiredError
+	if errors.As(err, &re) {
+		return KindRequired
+	}
+	return KindValidation
+}
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFieldError(t *testing.T) {
+	type Database struct {
+		Host string `env:"HOST" required:"true"`
+	}
+	type Config struct {
+		Port     int      `env:"PORT" max:"65535"`
+		Database Database `envPrefix:"DB_"`
+	}
+
+	tests := []struct {
+		name      string
+		values    map[string]string
+		fieldName string
+		envKey    string
+		kind      ErrorKind
+	}{
+		{"parse failure", map[string]string{"PORT": "abc", "DB_HOST": "db"}, "Port", "APP_PORT", KindParse},
+		{"validation failure", map[string]string{"PORT": "70000", "DB_HOST": "db"}, "Port", "APP_PORT", KindValidation},
+		{"required failure", map[string]string{"PORT": "8080"}, "Database.Host", "APP_DB_HOST", KindRequired},
+	}
+
+	loader := NewEnvLoader(WithPrefix("APP_"))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values := make(map[string]string, len(tt.values))
+			for k, v := range tt.values {
+				values["APP_"+k] = v
+			}
+
+			err := loader.LoadFromMap(values, &Config{})
+			var fe *FieldError
+			if assert.True(t, errors.As(err, &fe)) {
+				assert.Equal(t, tt.fieldName, fe.FieldName)
+				assert.Equal(t, tt.envKey, fe.EnvKey)
+				assert.Equal(t, tt.kind, fe.Kind)
+			}
+		})
+	}
+
+	t.Run("unwraps to the cause", func(t *testing.T) {
+		err := loader.LoadFromMap(map[string]string{"APP_PORT": "abc", "APP_DB_HOST": "db"}, &Config{})
+		assert.ErrorIs(t, err, strconv.ErrSyntax)
+		assert.EqualError(t, err, `env APP_PORT (field Port): strconv.ParseInt: parsing "abc": invalid syntax`)
+	})
+
+	t.Run("validate without loading", func(t *testing.T) {
+		err := loader.Validate(&Config{Port: 8080})
+		var fe *FieldError
+		if assert.True(t, errors.As(err, &fe)) {
+			assert.Equal(t, "APP_DB_HOST", fe.EnvKey)
+			assert.Equal(t, KindRequired, fe.Kind)
+		}
+	})
+}
+
+func TestSecretParseErrorsAreRedacted(t *testing.T) {
+	type SecretConfig struct {
+		PIN     int    `env:"SECRET_PIN" secret:"true"`
+		Port    int    `env:"SECRET_PORT"`
+		Key     []byte `env:"SECRET_KEY" secret:"true" encoding:"hex"`
+		Retries int    `env:"SECRET_RETRIES"`
+	}
+
+	loader := NewEnvLoader(WithErrorAggregation())
+	err := loader.LoadFromMap(map[string]string{
+		"SECRET_PIN":  "12ab34",
+		"SECRET_PORT": "80ab",
+		"SECRET_KEY":  "zz-topsecret",
+	}, &SecretConfig{})
+
+	assert.NotContains(t, err.Error(), "12ab34")
+	assert.NotContains(t, err.Error(), "topsecret")
+	assert.Contains(t, err.Error(), "env SECRET_PIN (field PIN): "+ErrRedactedValue+": invalid syntax")
+	assert.Contains(t, err.Error(), "env SECRET_KEY (field Key): "+ErrRedactedValue)
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+
+	// Non-secret fields keep the value for debugging
+	assert.Contains(t, err.Error(), `env SECRET_PORT (field Port): strconv.ParseInt: parsing "80ab": invalid syntax`)
+}
+
+func TestErrorKind_String(t *testing.T) {
+	assert.Equal(t, "parse", KindParse.String())
+	assert.Equal(t, "validation", KindValidation.String())
+	assert.Equal(t, "required", KindRequired.String())
+	assert.Equal(t, "ErrorKind(9)", ErrorKind(9).String())
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"slices"
+)
+
+// WithExpansion expands ${VAR} and $VAR references in values, resolving the
+// referenced names through the same sources as the value itself. Undefined
+// references expand to an empty string.
+func WithExpansion() Option {
+	return func(l *EnvLoader) {
+		l.expand = true
+	}
+}
+
+// WithStrictExpansion enables expansion like WithExpansion but reports an error
+// when a value references an undefined variable
+func WithStrictExpansion() Option {
+	return func(l *EnvLoader) {
+		l.expand = true
+		l.strictExpand = true
+	}
+}
+
+// expandValue expands references in value when expansion is enabled
+func (l *EnvLoader) expandValue(s *loadState, value string) (string, error) {
+	if !l.expand {
+		return value, nil
+	}
+	return s.expandRefs(value, l.strictExpand, nil)
+}
+
+// expandRefs expands references in value, recursing into referenced values.
+// seen holds the names being expanded to detect cycles.
+func (s *loadState) expandRefs(value string, strict bool, seen []string) (string, error) {
+	var err error
+	expanded := os.Expand(value, func(name string) string {
+		if err != nil {
+			return ""
+		}
+		if slices.Contains(seen, name) {
+			err = fmt.Errorf("cyclic reference to %q", name)
+			return ""
+		}
+
+		ref, ok := s.find(name)
+		if !ok {
+			if strict {
+				err = fmt.Errorf("undefined variable %q", name)
+			}
+			return ""
+		}
+
+		var refErr error
+		ref, refErr = s.expandRefs(ref, strict, append(seen, name))
+		if refErr != nil {
+			err = refErr
+		}
+		return ref
+	})
+	if err != nil {
+		return "", err
+	}
+	return expanded, nil
+}
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type expandConfig struct {
+	URL string `env:"URL"`
+}
+
+func TestWithExpansion(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  map[string]string
+		want    string
+		wantErr string
+	}{
+		{
+			name:   "resolved reference",
+			values: map[string]string{"URL": "https://${HOST}:${PORT}", "HOST": "example.com", "PORT": "8443"},
+			want:   "https://example.com:8443",
+		},
+		{
+			name:   "nested reference",
+			values: map[string]string{"URL": "${BASE}/api", "BASE": "https://${HOST}", "HOST": "example.com"},
+			want:   "https://example.com/api",
+		},
+		{
+			name:   "undefined reference expands to empty",
+			values: map[string]string{"URL": "https://${HOST}/api"},
+			want:   "https:///api",
+		},
+		{
+			name:    "cyclic reference",
+			values:  map[string]string{"URL": "${A}", "A": "${B}", "B": "${A}"},
+			wantErr: `env URL (field URL): cyclic reference to "A"`,
+		},
+	}
+
+	loader := NewEnvLoader(WithExpansion())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &expandConfig{}
+			err := loader.LoadFromMap(tt.values, cfg)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, cfg.URL)
+		})
+	}
+}
+
+func TestWithStrictExpansion(t *testing.T) {
+	loader := NewEnvLoader(WithStrictExpansion())
+
+	cfg := &expandConfig{}
+	err := loader.LoadFromMap(map[string]string{"URL": "https://${HOST}/api"}, cfg)
+	assert.EqualError(t, err, `env URL (field URL): undefined variable "HOST"`)
+
+	err = loader.LoadFromMap(map[string]string{"URL": "https://${HOST}/api", "HOST": "example.com"}, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/api", cfg.URL)
+}
+
+func TestExpansionDisabledByDefault(t *testing.T) {
+	cfg := &expandConfig{}
+	err := NewEnvLoader().LoadFromMap(map[string]string{"URL": "${HOST}", "HOST": "example.com"}, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, "${HOST}", cfg.URL)
+}
+
+func TestExpansionFromEnvironment(t *testing.T) {
+	type Config struct {
+		Addr string `env:"EXPAND_ADDR" default:"${EXPAND_HOST}:80"`
+	}
+
+	os.Setenv("EXPAND_HOST", "localhost")
+	defer os.Unsetenv("EXPAND_HOST")
+
+	cfg := &Config{}
+	err := NewEnvLoader(WithExpansion()).LoadConfig(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, "localhost:80", cfg.Addr)
+}
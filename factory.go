@@ -0,0 +1,82 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// InterfaceFactory returns a new value for an interface field given the value of
+// its discriminator variable. Returning a pointer to a struct lets the loader
+// populate the struct's own fields.
+type InterfaceFactory func(kind string) (interface{}, error)
+
+// WithInterfaceFactory registers a factory for fields of interface type iface.
+// The field's env tag names the discriminator variable, e.g. STORE_KIND=s3, and
+// the struct produced by the factory is loaded like a nested struct, honouring
+// the field's envPrefix tag.
+func WithInterfaceFactory(iface reflect.Type, factory InterfaceFactory) Option {
+	return func(l *EnvLoader) {
+		l.factories[iface] = factory
+	}
+}
+
+// isFactoryField reports whether the field is an interface with a registered factory
+func (l *EnvLoader) isFactoryField(field reflect.Value) bool {
+	if field.Kind() != reflect.Interface {
+		return false
+	}
+	_, ok := l.factories[field.Type()]
+	return ok
+}
+
+// loadFactoryField reads the discriminator, builds the concrete value with the
+// registered factory, and loads it. The field stays nil when the discriminator
+// is unset.
+func (l *EnvLoader) loadFactoryField(s *loadState, field reflect.Value, fieldType reflect.StructField, path string) error {
+	envKey := l.fullKey(s.prefix, fieldType)
+	if envKey == "" {
+		return nil
+	}
+	if !field.CanSet() {
+		return fieldError(envKey, path, KindParse, errors.New(ErrUnexportedField))
+	}
+
+	kind, _ := l.getEnvValueWithDefault(s, envKey, fieldType)
+	if kind == "" {
+		if err := l.validateField(field, fieldType); err != nil {
+			return fieldError(envKey, path, validationKind(err), err)
+		}
+		return nil
+	}
+
+	obj, err := l.factories[field.Type()](kind)
+	if err != nil {
+		return fieldError(envKey, path, KindParse, err)
+	}
+	value := reflect.ValueOf(obj)
+	if !value.IsValid() || !value.Type().AssignableTo(field.Type()) {
+		return fieldError(envKey, path, KindParse, fmt.Errorf("factory returned %T, which does not implement %v", obj, field.Type()))
+	}
+
+	if value.Kind() == reflect.Ptr && !value.IsNil() && l.isNestedStructType(value.Type().Elem()) {
+		if err := l.loadNested(s, value.Elem(), fieldType, path); err != nil {
+			return err
+		}
+	}
+
+	field.Set(value)
+	return nil
+}
+
+// factoryStruct returns the struct behind a populated factory field
+func (l *EnvLoader) factoryStruct(field reflect.Value) (reflect.Value, bool) {
+	if !l.isFactoryField(field) || field.IsNil() {
+		return reflect.Value{}, false
+	}
+	elem := field.Elem()
+	if elem.Kind() != reflect.Ptr || elem.IsNil() || !l.isNestedStructType(elem.Type().Elem()) {
+		return reflect.Value{}, false
+	}
+	return elem.Elem(), true
+}
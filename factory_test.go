@@ -0,0 +1,88 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Storage interface {
+	Name() string
+}
+
+type S3Storage struct {
+	Bucket string `env:"BUCKET" required:"true"`
+	Region string `env:"REGION" default:"us-east-1"`
+}
+
+func (s *S3Storage) Name() string { return "s3" }
+
+type LocalStorage struct {
+	Dir string `env:"DIR" default:"/var/data"`
+}
+
+func (s *LocalStorage) Name() string { return "local" }
+
+func newStorage(kind string) (interface{}, error) {
+	switch kind {
+	case "s3":
+		return &S3Storage{}, nil
+	case "local":
+		return &LocalStorage{}, nil
+	default:
+		return nil, fmt.Errorf("unknown storage kind %q", kind)
+	}
+}
+
+type StorageConfig struct {
+	Store Storage `env:"STORE_KIND" envPrefix:"STORE_"`
+}
+
+func TestWithInterfaceFactory(t *testing.T) {
+	loader := NewEnvLoader(WithInterfaceFactory(reflect.TypeOf((*Storage)(nil)).Elem(), newStorage))
+
+	t.Run("s3", func(t *testing.T) {
+		cfg := &StorageConfig{}
+		err := loader.LoadFromMap(map[string]string{"STORE_KIND": "s3", "STORE_BUCKET": "assets"}, cfg)
+		assert.NoError(t, err)
+		assert.Equal(t, &S3Storage{Bucket: "assets", Region: "us-east-1"}, cfg.Store)
+	})
+
+	t.Run("local", func(t *testing.T) {
+		cfg := &StorageConfig{}
+		err := loader.LoadFromMap(map[string]string{"STORE_KIND": "local", "STORE_DIR": "/tmp/data"}, cfg)
+		assert.NoError(t, err)
+		assert.Equal(t, &LocalStorage{Dir: "/tmp/data"}, cfg.Store)
+	})
+
+	t.Run("unset discriminator", func(t *testing.T) {
+		cfg := &StorageConfig{}
+		assert.NoError(t, loader.LoadFromMap(map[string]string{}, cfg))
+		assert.Nil(t, cfg.Store)
+	})
+
+	t.Run("unknown kind", func(t *testing.T) {
+		err := loader.LoadFromMap(map[string]string{"STORE_KIND": "ftp"}, &StorageConfig{})
+		assert.EqualError(t, err, `env STORE_KIND (field Store): unknown storage kind "ftp"`)
+	})
+
+	t.Run("nested errors carry the path", func(t *testing.T) {
+		err := loader.LoadFromMap(map[string]string{"STORE_KIND": "s3"}, &StorageConfig{})
+		assert.EqualError(t, err, "env STORE_BUCKET (field Store.Bucket): "+ErrRequiredField)
+	})
+
+	t.Run("wrong type from factory", func(t *testing.T) {
+		bad := NewEnvLoader(WithInterfaceFactory(reflect.TypeOf((*Storage)(nil)).Elem(), func(string) (interface{}, error) {
+			return "not storage", nil
+		}))
+		err := bad.LoadFromMap(map[string]string{"STORE_KIND": "s3"}, &StorageConfig{})
+		assert.ErrorContains(t, err, "factory returned string, which does not implement config.Storage")
+	})
+
+	t.Run("validate walks the concrete struct", func(t *testing.T) {
+		cfg := &StorageConfig{Store: &S3Storage{}}
+		assert.EqualError(t, loader.Validate(cfg), "env STORE_BUCKET (field Store.Bucket): "+ErrRequiredField)
+	})
+}
@@ -0,0 +1,108 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// fieldTemplates returns the exported, env-backed string fields of t whose
+// default tag references a sibling field as ${Name}, e.g. default:"${Host}:9090"
+func (l *EnvLoader) fieldTemplates(t reflect.Type, prefix string) map[string]bool {
+	var templates map[string]bool
+	for i := 0; i < t.NumField(); i++ {
+		fieldType := t.Field(i)
+		if fieldType.Type.Kind() != reflect.String || !fieldType.IsExported() || l.fullKey(prefix, fieldType) == "" {
+			continue
+		}
+		if len(fieldRefs(t, fieldType.Tag.Get(DefaultTag))) == 0 {
+			continue
+		}
+		if templates == nil {
+			templates = make(map[string]bool)
+		}
+		templates[fieldType.Name] = true
+	}
+	return templates
+}
+
+// fieldRefs returns the names in template's ${Name} references that are exported fields of t
+func fieldRefs(t reflect.Type, template string) []string {
+	var refs []string
+	os.Expand(template, func(name string) string {
+		if f, ok := t.FieldByName(name); ok && f.IsExported() {
+			refs = append(refs, name)
+		}
+		return ""
+	})
+	return refs
+}
+
+// resolveTemplates sets the fields still holding a default that references
+// sibling fields, substituting the loaded sibling values. Templates referencing
+// other templates are resolved first; a cycle is reported once, on the field
+// where it was entered. A template referencing a field promoted through a nil
+// embedded pointer is left unresolved.
+func (l *EnvLoader) resolveTemplates(s *loadState, v reflect.Value, path string) []error {
+	if len(s.templates) == 0 {
+		return nil
+	}
+
+	t := v.Type()
+	done := make(map[string]bool)
+
+	var resolve func(name string, chain []string) error
+	resolve = func(name string, chain []string) error {
+		if done[name] || !s.templates[name] {
+			return nil
+		}
+		fieldType, _ := t.FieldByName(name)
+		envKey := l.fullKey(s.prefix, fieldType)
+		fieldPath := joinFieldPath(path, name)
+		if slices.Contains(chain, name) {
+			cycle := strings.Join(append(chain, name), " -> ")
+			return fieldError(envKey, fieldPath, KindParse, fmt.Errorf("%s: %s", ErrDefaultCycle, cycle))
+		}
+		defer func() { done[name] = true }()
+
+		template := fieldType.Tag.Get(DefaultTag)
+		for _, ref := range fieldRefs(t, template) {
+			if err := resolve(ref, append(chain, name)); err != nil {
+				return err
+			}
+		}
+
+		unreachable := false
+		value := os.Expand(template, func(ref string) string {
+			f, ok := t.FieldByName(ref)
+			if !ok || !f.IsExported() {
+				return "${" + ref + "}"
+			}
+			sibling, err := v.FieldByIndexErr(f.Index)
+			if err != nil {
+				// Promoted through a nil embedded pointer
+				unreachable = true
+				return ""
+			}
+			if field, ok := indirect(sibling); ok {
+				return fmt.Sprint(field.Interface())
+			}
+			return ""
+		})
+		field := v.FieldByName(name)
+		if unreachable || !field.CanSet() {
+			return nil
+		}
+		return l.setFieldValue(s, field, fieldType, fieldPath, envKey, value, OriginDefault)
+	}
+
+	var errs []error
+	for i := 0; i < t.NumField(); i++ {
+		if err := resolve(t.Field(i).Name, nil); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,103 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFieldReferenceDefaults(t *testing.T) {
+	type RefConfig struct {
+		Host        string        `env:"HOST" default:"localhost"`
+		Port        int           `env:"PORT" default:"8080"`
+		MetricsAddr string        `env:"METRICS_ADDR" default:"${Host}:9090"`
+		BaseURL     string        `env:"BASE_URL" default:"http://${Host}:${Port}"`
+		HealthURL   string        `env:"HEALTH_URL" default:"${BaseURL}/health"`
+		Timeout     time.Duration `env:"TIMEOUT" default:"5s"`
+		Label       string        `env:"LABEL" default:"${Timeout}/${Unknown}"`
+	}
+
+	tests := []struct {
+		name   string
+		values map[string]string
+		want   RefConfig
+	}{
+		{
+			name:   "defaults reference resolved fields",
+			values: map[string]string{"HOST": "api.local"},
+			want: RefConfig{
+				Host:        "api.local",
+				Port:        8080,
+				MetricsAddr: "api.local:9090",
+				BaseURL:     "http://api.local:8080",
+				HealthURL:   "http://api.local:8080/health",
+				Timeout:     5 * time.Second,
+				Label:       "5s/${Unknown}",
+			},
+		},
+		{
+			name:   "variables override templates",
+			values: map[string]string{"METRICS_ADDR": "0.0.0.0:9100", "BASE_URL": "https://example.com"},
+			want: RefConfig{
+				Host:        "localhost",
+				Port:        8080,
+				MetricsAddr: "0.0.0.0:9100",
+				BaseURL:     "https://example.com",
+				HealthURL:   "https://example.com/health",
+				Timeout:     5 * time.Second,
+				Label:       "5s/${Unknown}",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &RefConfig{}
+			err := NewEnvLoader().LoadFromMap(tt.values, cfg)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, *cfg)
+		})
+	}
+}
+
+func TestFieldReferenceDefaultCycles(t *testing.T) {
+	type SelfConfig struct {
+		Name string `env:"NAME" default:"${Name}-1"`
+	}
+	type CycleConfig struct {
+		A string `env:"A" default:"${B}"`
+		B string `env:"B" default:"${A}"`
+	}
+
+	err := NewEnvLoader().LoadFromMap(nil, &SelfConfig{})
+	assert.EqualError(t, err, "env NAME (field Name): "+ErrDefaultCycle+": Name -> Name")
+
+	err = NewEnvLoader(WithErrorAggregation()).LoadFromMap(nil, &CycleConfig{})
+	assert.EqualError(t, err, "env A (field A): "+ErrDefaultCycle+": A -> B -> A")
+
+	// A variable breaks the cycle
+	cfg := &CycleConfig{}
+	err = NewEnvLoader().LoadFromMap(map[string]string{"B": "set"}, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, CycleConfig{A: "set", B: "set"}, *cfg)
+}
+
+func TestFieldReferenceDefaultsSkipNonEnvFields(t *testing.T) {
+	type SkipConfig struct {
+		Host     string `env:"HOST" default:"localhost"`
+		addr     string `default:"${Host}:9090"`
+		Preset   string `env:"-" default:"${Host}:8080"`
+		Untagged string `default:"${Host}:7070"`
+	}
+
+	cfg := &SkipConfig{Preset: "kept"}
+	assert.NotPanics(t, func() {
+		err := NewEnvLoader().LoadFromMap(map[string]string{"HOST": "api.local"}, cfg)
+		assert.NoError(t, err)
+	})
+	assert.Equal(t, "api.local", cfg.Host)
+	assert.Empty(t, cfg.addr)
+	assert.Equal(t, "kept", cfg.Preset)
+	assert.Empty(t, cfg.Untagged)
+}
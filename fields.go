@@ -0,0 +1,103 @@
+package config
+
+import (
+	"reflect"
+)
+
+// fieldRef describes an env-backed field found while walking a config struct
+type fieldRef struct {
+	Parent reflect.Value // struct containing the field
+	Value  reflect.Value
+	Field  reflect.StructField
+	EnvKey string // full env name including prefixes
+	Path   string // dotted Go field path
+	// Placeholder marks a field of the example element walked for an empty
+	// indexed struct slice; its value is a zero value, not part of the config
+	Placeholder bool
+}
+
+// walkFields calls fn for every env-backed field in v, recursing into nested structs
+// and the elements of indexed struct slices, applying prefixes the same way loading
+// does. Nil struct pointers are walked through a zero value so the config is never
+// modified. An empty indexed struct slice is walked through one zero element, its
+// fields marked Placeholder and named with <n> as the index, e.g. BACKEND_<n>_HOST.
+func (l *EnvLoader) walkFields(v reflect.Value, prefix, path string, fn func(fieldRef) error) error {
+	t := v.Type()
+
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		fieldType := t.Field(i)
+		fieldPath := joinFieldPath(path, fieldType.Name)
+		// Unexported fields cannot be loaded or read; embedded structs are walked
+		// because their exported fields are promoted
+		if isSkipped(fieldType) || (!fieldType.IsExported() && !fieldType.Anonymous) {
+			continue
+		}
+
+		if l.isNestedStruct(field, fieldType) || l.isNestedStructPtr(field, fieldType) {
+			nested := field
+			if field.Kind() == reflect.Ptr {
+				if field.IsNil() {
+					nested = reflect.New(field.Type().Elem())
+				}
+				nested = nested.Elem()
+			}
+			if err := l.walkFields(nested, l.nestedPrefix(prefix, fieldType), nestedPath(path, fieldType), fn); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if l.isStructSlice(field, fieldType) {
+			for j := 0; j < field.Len(); j++ {
+				if err := l.walkFields(field.Index(j), l.indexPrefix(prefix, fieldType, j), indexPath(fieldPath, j), fn); err != nil {
+					return err
+				}
+			}
+			if field.Len() == 0 {
+				elem := reflect.New(field.Type().Elem()).Elem()
+				placeholder := func(f fieldRef) error {
+					f.Placeholder = true
+					return fn(f)
+				}
+				elemPrefix := l.elemPrefix(prefix, fieldType, indexPlaceholder)
+				if err := l.walkFields(elem, elemPrefix, fieldPath+"["+indexPlaceholder+"]", placeholder); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		envKey := l.fullKey(prefix, fieldType)
+		if envKey == "" {
+			continue
+		}
+
+		ref := fieldRef{Parent: v, Value: field, Field: fieldType, EnvKey: envKey, Path: fieldPath}
+		if err := fn(ref); err != nil {
+			return err
+		}
+
+		// The concrete struct behind a factory-built interface field
+		if nested, ok := l.factoryStruct(field); ok {
+			if err := l.walkFields(nested, l.nestedPrefix(prefix, fieldType), fieldPath, fn); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// configStruct returns the struct behind cfg for read-only inspection.
+// A nil struct pointer yields a zero value of the struct type.
+func configStruct(cfg interface{}) (reflect.Value, bool) {
+	v := reflect.ValueOf(cfg)
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v = reflect.New(v.Type().Elem())
+		}
+		v = v.Elem()
+	}
+	return v, v.Kind() == reflect.Struct
+}
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// fieldGroup collects the members of a group:"name" tag within one struct
+type fieldGroup struct {
+	name string
+	keys []string // env names of the members
+	set  int      // number of members with a non-zero value
+	min  string   // group_required tag, taken from the first member that has one
+	max  string   // group_max tag, taken from the first member that has one
+}
+
+// validateGroups enforces group_required and group_max across the fields of v
+// sharing a group tag, e.g. exactly one of several auth methods with
+// group:"auth" group_required:"1" group_max:"1". Nested structs form their own
+// groups.
+func (l *EnvLoader) validateGroups(v reflect.Value, prefix, path string) []error {
+	t := v.Type()
+	var groups []*fieldGroup
+	byName := make(map[string]*fieldGroup)
+
+	for i := 0; i < v.NumField(); i++ {
+		fieldType := t.Field(i)
+		name := fieldType.Tag.Get(GroupTag)
+		envKey := l.fullKey(prefix, fieldType)
+		// Unexported members cannot be read; loading reports them instead
+		if name == "" || envKey == "" || !fieldType.IsExported() {
+			continue
+		}
+
+		g, ok := byName[name]
+		if !ok {
+			g = &fieldGroup{name: name}
+			byName[name] = g
+			groups = append(groups, g)
+		}
+		g.keys = append(g.keys, envKey)
+		if field, ok := indirect(v.Field(i)); ok && !isZeroValue(field) {
+			g.set++
+		}
+		if g.min == "" {
+			g.min = fieldType.Tag.Get(GroupRequiredTag)
+		}
+		if g.max == "" {
+			g.max = fieldType.Tag.Get(GroupMaxTag)
+		}
+	}
+
+	var errs []error
+	for _, g := range groups {
+		if err := g.check(); err != nil {
+			msg := fmt.Sprintf("group %s (%s)", g.name, strings.Join(g.keys, ", "))
+			if path != "" {
+				msg = path + ": " + msg
+			}
+			errs = append(errs, fmt.Errorf("%s: %w", msg, err))
+		}
+	}
+	return errs
+}
+
+// check compares the number of set members with the group's bounds
+func (g *fieldGroup) check() error {
+	if g.min != "" {
+		required, err := strconv.Atoi(g.min)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q", GroupRequiredTag, g.min)
+		}
+		if g.set < required {
+			return fmt.Errorf("%s: %d set, need at least %d", ErrGroupTooFew, g.set, required)
+		}
+	}
+	if g.max != "" {
+		limit, err := strconv.Atoi(g.max)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q", GroupMaxTag, g.max)
+		}
+		if g.set > limit {
+			return fmt.Errorf("%s: %d set, at most %d allowed", ErrGroupTooMany, g.set, limit)
+		}
+	}
+	return nil
+}
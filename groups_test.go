@@ -0,0 +1,88 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type groupAuth struct {
+	Token    string `env:"TOKEN" group:"auth" group_required:"1" group_max:"1"`
+	User     string `env:"USER" group:"auth"`
+	CertFile string `env:"CERT_FILE" group:"auth"`
+}
+
+type groupConfig struct {
+	Auth groupAuth `envPrefix:"AUTH_"`
+}
+
+func TestGroupValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  map[string]string
+		wantErr string
+	}{
+		{
+			name:    "none set",
+			values:  map[string]string{},
+			wantErr: "Auth: group auth (AUTH_TOKEN, AUTH_USER, AUTH_CERT_FILE): " + ErrGroupTooFew + ": 0 set, need at least 1",
+		},
+		{
+			name:   "one set",
+			values: map[string]string{"AUTH_USER": "admin"},
+		},
+		{
+			name:    "two set when max is one",
+			values:  map[string]string{"AUTH_TOKEN": "t", "AUTH_CERT_FILE": "/cert.pem"},
+			wantErr: "Auth: group auth (AUTH_TOKEN, AUTH_USER, AUTH_CERT_FILE): " + ErrGroupTooMany + ": 2 set, at most 1 allowed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewEnvLoader().LoadFromMap(tt.values, &groupConfig{})
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGroupValidation_InvalidTag(t *testing.T) {
+	type BadGroup struct {
+		A string `env:"A" group:"g" group_required:"one"`
+	}
+
+	err := NewEnvLoader().LoadFromMap(map[string]string{"A": "x"}, &BadGroup{})
+	assert.EqualError(t, err, `group g (A): invalid group_required "one"`)
+}
+
+func TestGroupValidation_Validate(t *testing.T) {
+	loader := NewEnvLoader()
+
+	err := loader.Validate(&groupConfig{Auth: groupAuth{Token: "t", CertFile: "/cert.pem"}})
+	assert.EqualError(t, err, "Auth: group auth (AUTH_TOKEN, AUTH_USER, AUTH_CERT_FILE): "+ErrGroupTooMany+": 2 set, at most 1 allowed")
+
+	err = loader.Validate(&groupConfig{})
+	assert.ErrorContains(t, err, ErrGroupTooFew)
+
+	assert.NoError(t, loader.Validate(&groupConfig{Auth: groupAuth{User: "admin"}}))
+}
+
+func TestGroupValidation_UnexportedMember(t *testing.T) {
+	type HiddenGroup struct {
+		Token   string `env:"TOKEN" group:"auth" group_max:"1"`
+		enabled bool   `env:"ENABLED" group:"auth"`
+	}
+
+	loader := NewEnvLoader(WithErrorAggregation())
+	assert.NotPanics(t, func() {
+		err := loader.LoadFromMap(map[string]string{"TOKEN": "t"}, &HiddenGroup{})
+		assert.EqualError(t, err, "env ENABLED (field enabled): "+ErrUnexportedField)
+	})
+	assert.NotPanics(t, func() {
+		assert.NoError(t, loader.Validate(&HiddenGroup{Token: "t"}))
+	})
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// errStopWalk ends a walkFields traversal early
+var errStopWalk = errors.New("stop walk")
+
+// isStructSlice reports whether the field is a slice of structs loaded from
+// indexed variables, e.g. BACKEND_0_HOST, BACKEND_1_HOST for envPrefix:"BACKEND_"
+func (l *EnvLoader) isStructSlice(field reflect.Value, fieldType reflect.StructField) bool {
+	return field.Kind() == reflect.Slice &&
+		!isJSONField(fieldType) &&
+		fieldType.Tag.Get(EnvPrefixTag) != "" &&
+		l.isNestedStructType(field.Type().Elem())
+}
+
+// indexPlaceholder stands for the index in the env names of an empty indexed
+// struct slice listed by Usage and Describe
+const indexPlaceholder = "<n>"
+
+// indexPrefix returns the env prefix for element i of an indexed struct slice
+func (l *EnvLoader) indexPrefix(prefix string, fieldType reflect.StructField, i int) string {
+	return l.elemPrefix(prefix, fieldType, strconv.Itoa(i))
+}
+
+// elemPrefix returns the env prefix for the element of an indexed struct slice
+// at the given index segment
+func (l *EnvLoader) elemPrefix(prefix string, fieldType reflect.StructField, index string) string {
+	sep := l.prefixSep
+	if sep == "" {
+		sep = "_"
+	}
+	return l.nestedPrefix(prefix, fieldType) + index + sep
+}
+
+// indexPath returns the field path for element i of a slice
+func indexPath(path string, i int) string {
+	return fmt.Sprintf("%s[%d]", path, i)
+}
+
+// loadStructSlice loads elements from contiguous indices starting at 0 and stops
+// at the first index for which no field has a value. The field is left unchanged
+// when index 0 has no values. Indexed loading is rejected under WithKeyFunc.
+func (l *EnvLoader) loadStructSlice(s *loadState, field reflect.Value, fieldType reflect.StructField, path string) error {
+	if !field.CanSet() {
+		return fieldError(l.nestedPrefix(s.prefix, fieldType)+"*", path, KindParse, errors.New(ErrUnexportedField))
+	}
+
+	prefix := s.prefix
+	defer func() { s.prefix = prefix }()
+
+	elemType := field.Type().Elem()
+	slice := reflect.MakeSlice(field.Type(), 0, 0)
+	var errs []error
+
+	for i := 0; ; i++ {
+		s.prefix = l.indexPrefix(prefix, fieldType, i)
+		if !l.hasValues(s, elemType) {
+			break
+		}
+		if l.keyFunc != nil {
+			// The key func ignores the index prefix, so every index would
+			// find the same values
+			return fieldError(l.nestedPrefix(prefix, fieldType)+"*", path, KindParse, errors.New(ErrKeyFuncIndexed))
+		}
+
+		elem := reflect.New(elemType).Elem()
+		elemPath := indexPath(path, i)
+		initialize(elem)
+		err := l.loadStruct(s, elem, elemPath)
+		if err == nil {
+			err = afterLoad(elem, elemPath)
+		}
+		if err != nil {
+			if !l.aggregate {
+				return err
+			}
+			errs = appendErrors(errs, err)
+		}
+		slice = reflect.Append(slice, elem)
+	}
+
+	if slice.Len() > 0 {
+		field.Set(slice)
+	}
+	return errors.Join(errs...)
+}
+
+// hasValues reports whether any field of a struct of type t has a value in the
+// sources under the current prefix. Defaults do not count.
+func (l *EnvLoader) hasValues(s *loadState, t reflect.Type) bool {
+	err := l.walkFields(reflect.New(t).Elem(), s.prefix, "", func(f fieldRef) error {
+		if f.Placeholder {
+			return nil
+		}
+		if _, ok := s.find(f.EnvKey); ok {
+			return errStopWalk
+		}
+		return nil
+	})
+	return err == errStopWalk
+}
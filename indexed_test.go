@@ -0,0 +1,120 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type BackendConfig struct {
+	Host   string `env:"HOST" required:"true"`
+	Port   int    `env:"PORT" default:"80"`
+	Weight int    `env:"WEIGHT"`
+}
+
+type BackendsConfig struct {
+	Backends []BackendConfig `envPrefix:"BACKEND_"`
+}
+
+func TestIndexedStructSlice(t *testing.T) {
+	loader := NewEnvLoader()
+
+	t.Run("contiguous indices", func(t *testing.T) {
+		cfg := &BackendsConfig{}
+		err := loader.LoadFromMap(map[string]string{
+			"BACKEND_0_HOST": "a.local",
+			"BACKEND_0_PORT": "8080",
+			"BACKEND_1_HOST": "b.local",
+		}, cfg)
+		assert.NoError(t, err)
+		assert.Equal(t, []BackendConfig{
+			{Host: "a.local", Port: 8080},
+			{Host: "b.local", Port: 80},
+		}, cfg.Backends)
+	})
+
+	t.Run("gap terminates enumeration", func(t *testing.T) {
+		cfg := &BackendsConfig{}
+		err := loader.LoadFromMap(map[string]string{
+			"BACKEND_0_HOST": "a.local",
+			"BACKEND_1_HOST": "b.local",
+			"BACKEND_3_HOST": "d.local",
+		}, cfg)
+		assert.NoError(t, err)
+		assert.Len(t, cfg.Backends, 2)
+	})
+
+	t.Run("no elements", func(t *testing.T) {
+		cfg := &BackendsConfig{}
+		assert.NoError(t, loader.LoadFromMap(map[string]string{}, cfg))
+		assert.Nil(t, cfg.Backends)
+	})
+
+	t.Run("element errors carry the index", func(t *testing.T) {
+		cfg := &BackendsConfig{}
+		err := loader.LoadFromMap(map[string]string{
+			"BACKEND_0_HOST":   "a.local",
+			"BACKEND_1_WEIGHT": "5",
+		}, cfg)
+		assert.EqualError(t, err, "env BACKEND_1_HOST (field Backends[1].Host): "+ErrRequiredField)
+
+		err = loader.LoadFromMap(map[string]string{
+			"BACKEND_0_HOST": "a.local",
+			"BACKEND_1_HOST": "b.local",
+			"BACKEND_1_PORT": "http",
+		}, &BackendsConfig{})
+		var fieldErr *FieldError
+		assert.ErrorAs(t, err, &fieldErr)
+		assert.Equal(t, "Backends[1].Port", fieldErr.FieldName)
+		assert.Equal(t, KindParse, fieldErr.Kind)
+	})
+
+	t.Run("prefixes compose", func(t *testing.T) {
+		cfg := &BackendsConfig{}
+		err := NewEnvLoader(WithPrefix("APP_")).LoadFromMap(map[string]string{"APP_BACKEND_0_HOST": "a.local"}, cfg)
+		assert.NoError(t, err)
+		assert.Equal(t, []BackendConfig{{Host: "a.local", Port: 80}}, cfg.Backends)
+	})
+
+	t.Run("validate and dump walk elements", func(t *testing.T) {
+		cfg := &BackendsConfig{Backends: []BackendConfig{{Host: "a.local"}, {}}}
+		assert.EqualError(t, loader.Validate(cfg), "env BACKEND_1_HOST (field Backends[1].Host): "+ErrRequiredField)
+		assert.Equal(t, "a.local", loader.Dump(cfg)["BACKEND_0_HOST"])
+	})
+
+	t.Run("empty slice lists placeholder names", func(t *testing.T) {
+		cfg := &BackendsConfig{}
+		usage := loader.Usage(cfg)
+		assert.Regexp(t, `BACKEND_<n>_HOST\s+string\s+\(required\)`, usage)
+		assert.Regexp(t, `BACKEND_<n>_PORT\s+int\s+\(default: 80\)`, usage)
+
+		fields := loader.Describe(cfg)
+		if assert.Len(t, fields, 3) {
+			assert.Equal(t, "Backends[<n>].Host", fields[0].Path)
+			assert.Equal(t, "BACKEND_<n>_HOST", fields[0].EnvKey)
+		}
+		assert.Contains(t, loader.GenerateEnvTemplate(cfg), "# required\n# BACKEND_<n>_HOST=\n")
+
+		// Placeholders are not values of the config
+		assert.NoError(t, loader.Validate(cfg))
+		assert.Empty(t, loader.Dump(cfg))
+		for _, d := range loader.Diff(cfg, &BackendsConfig{Backends: []BackendConfig{{Host: "a.local"}}}) {
+			assert.NotContains(t, d.Path, "<n>")
+		}
+	})
+
+	t.Run("unexported slice", func(t *testing.T) {
+		type hiddenBackends struct {
+			backends []BackendConfig `envPrefix:"BACKEND_"`
+		}
+
+		err := loader.LoadFromMap(map[string]string{"BACKEND_0_HOST": "a.local"}, &hiddenBackends{})
+		var fieldErr *FieldError
+		if assert.ErrorAs(t, err, &fieldErr) {
+			assert.Equal(t, "backends", fieldErr.FieldName)
+			assert.Equal(t, "BACKEND_*", fieldErr.EnvKey)
+			assert.Equal(t, KindParse, fieldErr.Kind)
+			assert.EqualError(t, fieldErr.Err, ErrUnexportedField)
+		}
+	})
+}
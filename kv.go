@@ -0,0 +1,89 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// WithSkipUnknownKV ignores keys in format:"kv" values that match no sub-field
+// instead of failing the load
+func WithSkipUnknownKV() Option {
+	return func(l *EnvLoader) {
+		l.skipUnknownKV = true
+	}
+}
+
+// isKVField reports whether the field is filled from a single key=value list
+func isKVField(fieldType reflect.StructField) bool {
+	return fieldType.Tag.Get(FormatTag) == FormatKV
+}
+
+// parseKV splits a value such as host=localhost;port=5432 on semicolons and
+// parses each value into the sub-field whose env name matches its key,
+// ignoring case. Empty pairs are skipped.
+func (l *EnvLoader) parseKV(value string, field reflect.Value) error {
+	if value == "" {
+		return nil
+	}
+
+	t := field.Type()
+	for _, pair := range strings.Split(value, ";") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, val, ok := strings.Cut(pair, "=")
+		if !ok {
+			return fmt.Errorf("%s, got %q", ErrKVPair, pair)
+		}
+		key = strings.TrimSpace(key)
+
+		i, ok := l.kvFieldIndex(t, key)
+		if !ok {
+			if l.skipUnknownKV {
+				continue
+			}
+			return fmt.Errorf("%s %q", ErrUnknownKVKey, key)
+		}
+		if err := l.parseField(strings.TrimSpace(val), field.Field(i), t.Field(i)); err != nil {
+			return fmt.Errorf("key %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// kvFieldIndex returns the index of the settable field of t whose env name matches key
+func (l *EnvLoader) kvFieldIndex(t reflect.Type, key string) (int, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		fieldType := t.Field(i)
+		if !fieldType.IsExported() {
+			continue
+		}
+		if name := l.envKey(fieldType); name != "" && strings.EqualFold(name, key) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// validateKV runs the validators on each env-backed sub-field of a parsed
+// format:"kv" struct and returns the name of the first failing sub-field
+func (l *EnvLoader) validateKV(field reflect.Value) (string, error) {
+	field, ok := indirect(field)
+	if !ok || field.Kind() != reflect.Struct {
+		return "", nil
+	}
+
+	t := field.Type()
+	for i := 0; i < t.NumField(); i++ {
+		fieldType := t.Field(i)
+		if !fieldType.IsExported() || l.envKey(fieldType) == "" {
+			continue
+		}
+		if err := l.validateField(field.Field(i), fieldType); err != nil {
+			return fieldType.Name, err
+		}
+	}
+	return "", nil
+}
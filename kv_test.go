@@ -0,0 +1,136 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type kvDatabase struct {
+	Host string `env:"HOST"`
+	Port int    `env:"PORT"`
+}
+
+type kvConfig struct {
+	DB    kvDatabase  `env:"DB" format:"kv"`
+	Cache *kvDatabase `env:"CACHE" format:"kv"`
+}
+
+func TestKVFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		values  map[string]string
+		want    kvConfig
+		wantErr string
+	}{
+		{
+			name:   "pairs fill sub-fields",
+			values: map[string]string{"DB": "host=localhost;port=5432", "CACHE": " HOST = redis ; "},
+			want:   kvConfig{DB: kvDatabase{Host: "localhost", Port: 5432}, Cache: &kvDatabase{Host: "redis"}},
+		},
+		{
+			name:   "unset",
+			values: map[string]string{},
+		},
+		{
+			name:    "unknown key",
+			values:  map[string]string{"DB": "host=localhost;user=admin"},
+			wantErr: `env DB (field DB): unknown key "user"`,
+		},
+		{
+			name:   "unknown key skipped",
+			opts:   []Option{WithSkipUnknownKV()},
+			values: map[string]string{"DB": "host=localhost;user=admin"},
+			want:   kvConfig{DB: kvDatabase{Host: "localhost"}},
+		},
+		{
+			name:    "missing equals",
+			values:  map[string]string{"DB": "localhost"},
+			wantErr: `expected key=value, got "localhost"`,
+		},
+		{
+			name:    "invalid value",
+			values:  map[string]string{"DB": "port=abc"},
+			wantErr: `key port: strconv.ParseInt: parsing "abc"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := kvConfig{}
+			err := NewEnvLoader(tt.opts...).LoadFromMap(tt.values, &cfg)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, cfg)
+		})
+	}
+}
+
+func TestKVFormat_ValidatesSubFields(t *testing.T) {
+	type kvSub struct {
+		Host string `env:"HOST" required:"true"`
+		Port int    `env:"PORT" max:"10"`
+	}
+	type kvValidated struct {
+		DB    kvSub  `env:"DB" format:"kv"`
+		Cache *kvSub `env:"CACHE" format:"kv"`
+	}
+
+	tests := []struct {
+		name     string
+		values   map[string]string
+		wantErr  string
+		wantPath string
+		wantKind ErrorKind
+	}{
+		{
+			name:   "valid",
+			values: map[string]string{"DB": "host=db;port=5", "CACHE": "host=redis"},
+		},
+		{
+			name:   "unset is not validated",
+			values: map[string]string{},
+		},
+		{
+			name:     "required sub-field missing",
+			values:   map[string]string{"DB": "port=5"},
+			wantErr:  ErrRequiredField,
+			wantPath: "DB.Host",
+			wantKind: KindRequired,
+		},
+		{
+			name:     "sub-field out of range",
+			values:   map[string]string{"DB": "host=db;port=99"},
+			wantErr:  ErrOutOfRange,
+			wantPath: "DB.Port",
+			wantKind: KindValidation,
+		},
+		{
+			name:     "pointer sub-field out of range",
+			values:   map[string]string{"CACHE": "host=redis;port=99"},
+			wantErr:  ErrOutOfRange,
+			wantPath: "Cache.Port",
+			wantKind: KindValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewEnvLoader().LoadFromMap(tt.values, &kvValidated{})
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+			var fe *FieldError
+			if assert.ErrorAs(t, err, &fe) {
+				assert.Equal(t, tt.wantPath, fe.FieldName)
+				assert.Equal(t, tt.wantKind, fe.Kind)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"strings"
+	"unicode"
+)
+
+// toScreamingSnake converts a CamelCase identifier to UPPER_SNAKE_CASE.
+// Acronyms are kept together, so "DatabaseURL" becomes "DATABASE_URL"
+// and "HTTPServer" becomes "HTTP_SERVER".
+func toScreamingSnake(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,30 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_toScreamingSnake(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Port", "PORT"},
+		{"DatabaseURL", "DATABASE_URL"},
+		{"HTTPPort", "HTTP_PORT"},
+		{"HTTPServer", "HTTP_SERVER"},
+		{"MaxConns", "MAX_CONNS"},
+		{"APIKey", "API_KEY"},
+		{"Http2Enabled", "HTTP2_ENABLED"},
+		{"ID", "ID"},
+		{"already_snake", "ALREADY_SNAKE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, toScreamingSnake(tt.name))
+		})
+	}
+}
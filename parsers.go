@@ -1,7 +1,17 @@
 package config
 
 import (
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"net"
+	"net/url"
 	"reflect"
 	"strconv"
 	"strings"
@@ -11,12 +21,31 @@ import (
 // StringParser parses string values into the target field type
 type StringParser struct{}
 
-// Parse converts a string value to the target field type
+// Parse converts a string value to the target field type. Like the other
+// parsers it leaves the field untouched when the value is empty.
 func (p *StringParser) Parse(value string, field reflect.Value) error {
+	if value == "" {
+		return nil
+	}
 	field.SetString(value)
 	return nil
 }
 
+// ParseWithTags normalizes the value to lower or upper case when the case tag
+// is set, before validators such as oneof see it
+func (p *StringParser) ParseWithTags(value string, field reflect.Value, tags reflect.StructTag) error {
+	switch c := tags.Get(CaseTag); c {
+	case "":
+	case CaseLower:
+		value = strings.ToLower(value)
+	case CaseUpper:
+		value = strings.ToUpper(value)
+	default:
+		return fmt.Errorf("%s %q", ErrUnknownCase, c)
+	}
+	return p.Parse(value, field)
+}
+
 // Int64Parser parses int64 values into the target field type
 type Int64Parser struct{}
 
@@ -25,6 +54,10 @@ func (p *Int64Parser) Parse(value string, field reflect.Value) error {
 	if value == "" {
 		return nil
 	}
+	value, err := stripDigitSeparators(value)
+	if err != nil {
+		return err
+	}
 	v, err := strconv.ParseInt(value, 10, 64)
 	if err != nil {
 		return err
@@ -33,24 +66,141 @@ func (p *Int64Parser) Parse(value string, field reflect.Value) error {
 	return nil
 }
 
+// ParseWithTags applies the size and base tags, see parseIntWithTags
+func (p *Int64Parser) ParseWithTags(value string, field reflect.Value, tags reflect.StructTag) error {
+	return parseIntWithTags(p, value, field, tags)
+}
+
 // IntParser parses int values into the target field type
 type IntParser struct{}
 
-// Parse converts a string value to an int and sets it to the target field
+// Parse converts a string value to an integer sized to the target field
 func (p *IntParser) Parse(value string, field reflect.Value) error {
 	if value == "" {
 		return nil
 	}
-	v, err := strconv.Atoi(value)
+	value, err := stripDigitSeparators(value)
+	if err != nil {
+		return err
+	}
+	v, err := strconv.ParseInt(value, 10, field.Type().Bits())
 	if err != nil {
 		return err
 	}
-	field.SetInt(int64(v))
+	field.SetInt(v)
+	return nil
+}
+
+// ParseWithTags applies the size and base tags, see parseIntWithTags
+func (p *IntParser) ParseWithTags(value string, field reflect.Value, tags reflect.StructTag) error {
+	return parseIntWithTags(p, value, field, tags)
+}
+
+// UintParser parses unsigned integer values into the target field type
+type UintParser struct{}
+
+// Parse converts a string value to an unsigned integer sized to the target field
+func (p *UintParser) Parse(value string, field reflect.Value) error {
+	if value == "" {
+		return nil
+	}
+	value, err := stripDigitSeparators(value)
+	if err != nil {
+		return err
+	}
+	v, err := strconv.ParseUint(value, 10, field.Type().Bits())
+	if err != nil {
+		return err
+	}
+	field.SetUint(v)
+	return nil
+}
+
+// ParseWithTags applies the size and base tags, see parseIntWithTags
+func (p *UintParser) ParseWithTags(value string, field reflect.Value, tags reflect.StructTag) error {
+	return parseIntWithTags(p, value, field, tags)
+}
+
+// parseIntWithTags reads byte sizes such as 10MB when the size tag is set, and
+// integers in another base when the base tag is set. Decimal values without a
+// size tag are left to p.
+func parseIntWithTags(p ValueParser, value string, field reflect.Value, tags reflect.StructTag) error {
+	if tags.Get(SizeTag) == TagTrue {
+		return (&ByteSizeParser{}).Parse(value, field)
+	}
+	base, err := intBase(tags, 36)
+	if err != nil {
+		return err
+	}
+	if base == 10 {
+		return p.Parse(value, field)
+	}
+	return parseIntBase(value, field, base)
+}
+
+// stripDigitSeparators removes underscores used to group digits, as in
+// 1_000_000. Each underscore must sit between two digits.
+func stripDigitSeparators(value string) (string, error) {
+	if !strings.Contains(value, "_") {
+		return value, nil
+	}
+	for i := 0; i < len(value); i++ {
+		if value[i] == '_' && (i == 0 || i == len(value)-1 || !isDigit(value[i-1]) || !isDigit(value[i+1])) {
+			return "", fmt.Errorf("%s: %q", ErrDigitSeparator, value)
+		}
+	}
+	return strings.ReplaceAll(value, "_", ""), nil
+}
+
+// isDigit reports whether c is an ASCII digit
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// intBase returns the base selected by the base tag: 10 by default, or 0 for
+// base:"auto", which detects the 0x, 0o and 0b prefixes and a leading 0 for octal
+func intBase(tags reflect.StructTag, maxBase int) (int, error) {
+	tag := tags.Get(BaseTag)
+	switch tag {
+	case "":
+		return 10, nil
+	case BaseAuto:
+		return 0, nil
+	}
+	base, err := strconv.Atoi(tag)
+	if err != nil || base == 1 || base < 0 || base > maxBase {
+		return 0, fmt.Errorf("invalid base %q", tag)
+	}
+	return base, nil
+}
+
+// parseIntBase parses an integer in base into a signed or unsigned integer field
+func parseIntBase(value string, field reflect.Value, base int) error {
+	if value == "" {
+		return nil
+	}
+	switch field.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v, err := strconv.ParseUint(value, base, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(v)
+	default:
+		v, err := strconv.ParseInt(value, base, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(v)
+	}
 	return nil
 }
 
 // SliceParser parses slice values into the target field type
-type SliceParser struct{}
+type SliceParser struct {
+	types typeParserProvider // type-specific element parsers; nil means the built-in ones
+	tags  reflect.StructTag  // field tags passed to element parsers implementing TagParser
+}
 
 // Parse converts a comma-separated string into a slice and sets it to the target field
 func (p *SliceParser) Parse(value string, field reflect.Value) error {
@@ -63,49 +213,383 @@ func (p *SliceParser) ParseWithContext(value string, field reflect.Value, parser
 		return nil
 	}
 
-	values := strings.Split(value, ",")
+	values, err := splitList(value, p.tags)
+	if err != nil {
+		return err
+	}
 	slice := reflect.MakeSlice(field.Type(), 0, len(values))
 
 	// Get the element parser either from the provided function or defaultParsers
-	var getParser func(reflect.Kind) (ValueParser, bool)
+	getParser := defaultParserProvider
 	if len(parserProvider) > 0 && parserProvider[0] != nil {
 		getParser = parserProvider[0]
-	} else {
-		getParser = func(k reflect.Kind) (ValueParser, bool) {
-			p, ok := defaultParsers[k]
-			return p, ok
-		}
 	}
 
-	elemParser, ok := getParser(field.Type().Elem().Kind())
+	elemParser, ok := elementParser(field.Type().Elem(), p.types, getParser)
 	if !ok {
-		return fmt.Errorf("unsupported slice element type: %v", field.Type().Elem().Kind())
+		return &unsupportedError{msg: fmt.Sprintf("unsupported slice element type: %v", field.Type().Elem().Kind())}
 	}
 
 	for _, v := range values {
 		elem := reflect.New(field.Type().Elem()).Elem()
-		if err := elemParser.Parse(v, elem); err != nil {
+		if err := parseValue(elemParser, v, elem, p.tags); err != nil {
+			return err
+		}
+		slice = reflect.Append(slice, elem)
+	}
+
+	if p.tags.Get(DedupTag) == TagTrue {
+		var err error
+		if slice, err = dedupSlice(slice); err != nil {
+			return err
+		}
+	}
+
+	field.Set(slice)
+	return nil
+}
+
+// splitList splits a comma-separated list. A backslash escapes a comma or another
+// backslash, so `a\,b,c` yields "a,b" and "c"; other backslashes are kept as is.
+// With csv:"true" the value is read as a CSV record instead.
+func splitList(value string, tags reflect.StructTag) ([]string, error) {
+	if tags.Get(CSVTag) == TagTrue {
+		return splitCSV(value)
+	}
+	if !strings.Contains(value, `\`) {
+		return strings.Split(value, ","), nil
+	}
+
+	var values []string
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case c == ',':
+			values = append(values, b.String())
+			b.Reset()
+		case c != '\\':
+			b.WriteByte(c)
+		case i == len(value)-1:
+			return nil, fmt.Errorf("%s: %q", ErrDanglingEscape, value)
+		case value[i+1] == ',' || value[i+1] == '\\':
+			i++
+			b.WriteByte(value[i])
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return append(values, b.String()), nil
+}
+
+// splitCSV reads value as a single CSV record, so `"a,b",c` yields "a,b" and "c"
+func splitCSV(value string) ([]string, error) {
+	r := csv.NewReader(strings.NewReader(value))
+	record, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV list: %w", err)
+	}
+	if _, err := r.Read(); err != io.EOF {
+		return nil, fmt.Errorf("invalid CSV list: expected a single line")
+	}
+	return record, nil
+}
+
+// dedupSlice removes repeated elements from a slice of comparable elements,
+// keeping the first occurrence of each
+func dedupSlice(slice reflect.Value) (reflect.Value, error) {
+	if !slice.Type().Elem().Comparable() {
+		return slice, fmt.Errorf("%s: %v", ErrDedupNotComparable, slice.Type().Elem())
+	}
+
+	seen := make(map[interface{}]bool, slice.Len())
+	unique := reflect.MakeSlice(slice.Type(), 0, slice.Len())
+	for i := 0; i < slice.Len(); i++ {
+		elem := slice.Index(i)
+		if key := elem.Interface(); !seen[key] {
+			seen[key] = true
+			unique = reflect.Append(unique, elem)
+		}
+	}
+	return unique, nil
+}
+
+// ArrayParser parses fixed-size array values into the target field type
+type ArrayParser struct {
+	types typeParserProvider // type-specific element parsers; nil means the built-in ones
+	tags  reflect.StructTag  // field tags passed to element parsers implementing TagParser
+}
+
+// Parse converts a comma-separated string into an array and sets it to the target field
+func (p *ArrayParser) Parse(value string, field reflect.Value) error {
+	return p.ParseWithContext(value, field)
+}
+
+// ParseWithContext provides the full functionality with parser provider.
+// The number of values must match the array length exactly.
+func (p *ArrayParser) ParseWithContext(value string, field reflect.Value, parserProvider ...func(reflect.Kind) (ValueParser, bool)) error {
+	if value == "" {
+		return nil
+	}
+
+	getParser := defaultParserProvider
+	if len(parserProvider) > 0 && parserProvider[0] != nil {
+		getParser = parserProvider[0]
+	}
+
+	elemParser, ok := elementParser(field.Type().Elem(), p.types, getParser)
+	if !ok {
+		return &unsupportedError{msg: fmt.Sprintf("unsupported array element type: %v", field.Type().Elem().Kind())}
+	}
+
+	values, err := splitList(value, p.tags)
+	if err != nil {
+		return err
+	}
+	if len(values) != field.Len() {
+		return fmt.Errorf("expected %d array elements, got %d", field.Len(), len(values))
+	}
+
+	array := reflect.New(field.Type()).Elem()
+	for i, v := range values {
+		if err := parseValue(elemParser, v, array.Index(i), p.tags); err != nil {
 			return err
 		}
+	}
+
+	field.Set(array)
+	return nil
+}
+
+// MapParser parses map values into the target field type
+type MapParser struct {
+	types typeParserProvider // type-specific element parsers; nil means the built-in ones
+	tags  reflect.StructTag  // field tags passed to element parsers implementing TagParser
+}
+
+// Parse converts a comma-separated list of key=value pairs into a map and sets it to the target field
+func (p *MapParser) Parse(value string, field reflect.Value) error {
+	return p.ParseWithContext(value, field)
+}
+
+// ParseWithContext provides the full functionality with parser provider
+func (p *MapParser) ParseWithContext(value string, field reflect.Value, parserProvider ...func(reflect.Kind) (ValueParser, bool)) error {
+	if value == "" {
+		return nil
+	}
+
+	getParser := defaultParserProvider
+	if len(parserProvider) > 0 && parserProvider[0] != nil {
+		getParser = parserProvider[0]
+	}
+
+	mapType := field.Type()
+	keyParser, ok := getParser(mapType.Key().Kind())
+	if !ok {
+		return &unsupportedError{msg: fmt.Sprintf("unsupported map key type: %v", mapType.Key().Kind())}
+	}
+	valueParser, ok := p.listParser(mapType.Elem(), getParser)
+	if !ok {
+		valueParser, ok = elementParser(mapType.Elem(), p.types, getParser)
+	}
+	if !ok {
+		return &unsupportedError{msg: fmt.Sprintf("unsupported map value type: %v", mapType.Elem().Kind())}
+	}
+	if d, ok := valueParser.(*delimitedListParser); ok && (d.delim == "" || strings.ContainsAny(d.delim, ",=")) {
+		return fmt.Errorf("invalid %s %q: must be non-empty and not contain ',' or '='", ValueDelimiterTag, d.delim)
+	}
+
+	pairs := strings.Split(value, ",")
+	m := reflect.MakeMapWithSize(mapType, len(pairs))
+
+	for _, pair := range pairs {
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok {
+			return fmt.Errorf("invalid map entry %q: expected key=value", pair)
+		}
+		if k == "" {
+			return fmt.Errorf("invalid map entry %q: empty key", pair)
+		}
+
+		key := reflect.New(mapType.Key()).Elem()
+		if err := keyParser.Parse(k, key); err != nil {
+			return fmt.Errorf("map key %q: %w", k, err)
+		}
+		elem := reflect.New(mapType.Elem()).Elem()
+		if err := parseValue(valueParser, v, elem, p.tags); err != nil {
+			return fmt.Errorf("map value for key %q: %w", k, err)
+		}
+		m.SetMapIndex(key, elem)
+	}
+
+	field.Set(m)
+	return nil
+}
+
+// listParser returns a parser for slice-valued maps such as map[string][]string,
+// splitting each value on the value_delimiter tag ("|" by default). Slice types
+// with their own parser, such as net.IP, or that unmarshal text are not lists.
+func (p *MapParser) listParser(t reflect.Type, getParser func(reflect.Kind) (ValueParser, bool)) (ValueParser, bool) {
+	if t.Kind() != reflect.Slice {
+		return nil, false
+	}
+	types := p.types
+	if types == nil {
+		types = builtinTypeParser
+	}
+	if _, ok := types(t); ok || isTextUnmarshaler(t) {
+		return nil, false
+	}
+	elem, ok := elementParser(t.Elem(), p.types, getParser)
+	if !ok {
+		return nil, false
+	}
+	delim, ok := p.tags.Lookup(ValueDelimiterTag)
+	if !ok {
+		delim = "|"
+	}
+	return &delimitedListParser{delim: delim, elem: elem}, true
+}
+
+// delimitedListParser parses a delimited list, such as a map value 1|2, into a slice
+type delimitedListParser struct {
+	delim string
+	elem  ValueParser
+}
+
+// Parse converts a delimited list into a slice and sets it to the target field
+func (p *delimitedListParser) Parse(value string, field reflect.Value) error {
+	return p.ParseWithTags(value, field, "")
+}
+
+// ParseWithTags parses each element with the element parser, passing tags along
+func (p *delimitedListParser) ParseWithTags(value string, field reflect.Value, tags reflect.StructTag) error {
+	if value == "" {
+		return nil
+	}
+
+	parts := strings.Split(value, p.delim)
+	slice := reflect.MakeSlice(field.Type(), 0, len(parts))
+	for i, part := range parts {
+		elem := reflect.New(field.Type().Elem()).Elem()
+		if err := parseValue(p.elem, part, elem, tags); err != nil {
+			return fmt.Errorf("element %d: %w", i, err)
+		}
 		slice = reflect.Append(slice, elem)
 	}
+	field.Set(slice)
+	return nil
+}
+
+// JSONParser decodes JSON values into the target field, e.g. slices of structs
+type JSONParser struct{}
+
+// Parse unmarshals a JSON string into a new value and sets it to the target field.
+// Slice elements are decoded one by one so an error names the failing element.
+func (p *JSONParser) Parse(value string, field reflect.Value) error {
+	if value == "" {
+		return nil
+	}
+
+	if field.Kind() == reflect.Slice && field.Type().Elem().Kind() != reflect.Uint8 {
+		var raw []json.RawMessage
+		if err := json.Unmarshal([]byte(value), &raw); err == nil && raw != nil {
+			return p.parseElements(raw, field)
+		}
+	}
+
+	v := reflect.New(field.Type())
+	if err := json.Unmarshal([]byte(value), v.Interface()); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	field.Set(v.Elem())
+	return nil
+}
 
+// parseElements decodes each raw element into a new slice and sets it to the target field
+func (p *JSONParser) parseElements(raw []json.RawMessage, field reflect.Value) error {
+	slice := reflect.MakeSlice(field.Type(), len(raw), len(raw))
+	for i, r := range raw {
+		if err := json.Unmarshal(r, slice.Index(i).Addr().Interface()); err != nil {
+			path := jsonErrorPath(fmt.Sprintf("[%d]", i), field.Type().Elem(), err)
+			return &elementError{path: path, err: fmt.Errorf("invalid JSON: %w", err)}
+		}
+	}
 	field.Set(slice)
 	return nil
 }
 
+// jsonErrorPath extends path with the Go names of the struct fields a JSON type
+// error points at, e.g. [1] becomes [1].Port for a bad "port" value
+func jsonErrorPath(path string, t reflect.Type, err error) string {
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &typeErr) || typeErr.Field == "" {
+		return path
+	}
+
+	for _, name := range strings.Split(typeErr.Field, ".") {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			break
+		}
+		sf, ok := jsonField(t, name)
+		if !ok {
+			break
+		}
+		path += "." + sf.Name
+		t = sf.Type
+	}
+	return path
+}
+
+// jsonField finds the field of struct type t that encoding/json decodes name into
+func jsonField(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if tag == name || (tag == "" && strings.EqualFold(sf.Name, name)) {
+			return sf, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
 // DurationParser parses duration values into the target field type
 type DurationParser struct{}
 
-// Parse converts a string value to a time.Duration and sets it to the target field
+// Parse converts a string value to a time.Duration and sets it to the target field.
+// Bare integers, including negative ones, are read as seconds. Default tags go
+// through the same parser, so default:"30" means 30s.
 func (p *DurationParser) Parse(value string, field reflect.Value) error {
+	return p.ParseWithTags(value, field, "")
+}
+
+// ParseWithTags converts a duration, reading bare integers in the unit given by
+// the unit tag (ns, us, ms, s, m or h; seconds by default). Values with an
+// explicit unit ignore the tag. With format:"clock" the value is read as
+// HH:MM:SS or MM:SS instead.
+func (p *DurationParser) ParseWithTags(value string, field reflect.Value, tags reflect.StructTag) error {
+	if tags.Get(FormatTag) == FormatClock {
+		return parseClock(value, field)
+	}
+
+	unit := tags.Get(UnitTag)
+	if unit == "" {
+		unit = "s"
+	}
+	if !durationUnits[unit] {
+		return fmt.Errorf("%s %q", ErrUnknownUnit, unit)
+	}
+
 	if value == "" {
 		return nil
 	}
 
-	// If no time unit is specified, assume seconds
+	// If no time unit is specified, use the field's unit
 	if _, err := strconv.Atoi(value); err == nil {
-		value += "s"
+		value += unit
 	}
 
 	d, err := time.ParseDuration(value)
@@ -119,19 +603,49 @@ func (p *DurationParser) Parse(value string, field reflect.Value) error {
 // BoolParser parses boolean values into the target field type
 type BoolParser struct{}
 
-// Parse converts a string value to a bool and sets it to the target field
+// Parse converts a string value to a bool and sets it to the target field.
+// Besides the values accepted by strconv.ParseBool it accepts yes/no and on/off
+// in any case.
 func (p *BoolParser) Parse(value string, field reflect.Value) error {
 	if value == "" {
 		return nil
 	}
 	v, err := strconv.ParseBool(value)
 	if err != nil {
-		return err
+		var ok bool
+		if v, ok = boolWords[strings.ToLower(value)]; !ok {
+			return err
+		}
 	}
 	field.SetBool(v)
 	return nil
 }
 
+// ParseWithTags accepts only the literals true and false, in any case, when
+// the field is tagged strict_bool:"true"
+func (p *BoolParser) ParseWithTags(value string, field reflect.Value, tags reflect.StructTag) error {
+	if tags.Get(StrictBoolTag) != TagTrue || value == "" {
+		return p.Parse(value, field)
+	}
+	switch {
+	case strings.EqualFold(value, "true"):
+		field.SetBool(true)
+	case strings.EqualFold(value, "false"):
+		field.SetBool(false)
+	default:
+		return fmt.Errorf("%s, got %q", ErrStrictBool, value)
+	}
+	return nil
+}
+
+// boolWords maps the human-friendly boolean tokens to their values
+var boolWords = map[string]bool{
+	"yes": true,
+	"on":  true,
+	"no":  false,
+	"off": false,
+}
+
 // Float64Parser parses float64 values into the target field type
 type Float64Parser struct{}
 
@@ -140,6 +654,10 @@ func (p *Float64Parser) Parse(value string, field reflect.Value) error {
 	if value == "" {
 		return nil
 	}
+	value, err := stripDigitSeparators(value)
+	if err != nil {
+		return err
+	}
 	v, err := strconv.ParseFloat(value, 64)
 	if err != nil {
 		return err
@@ -148,12 +666,416 @@ func (p *Float64Parser) Parse(value string, field reflect.Value) error {
 	return nil
 }
 
-// defaultParsers maps reflect.Kind to their respective ValueParser implementations
+// ParseWithTags applies percent handling when the percent tag is set
+func (p *Float64Parser) ParseWithTags(value string, field reflect.Value, tags reflect.StructTag) error {
+	if mode := tags.Get(PercentTag); mode != "" {
+		return parsePercent(value, field, mode)
+	}
+	return p.Parse(value, field)
+}
+
+// Float32Parser parses float32 values into the target field type
+type Float32Parser struct{}
+
+// Parse converts a string value to a float32 and sets it to the target field
+func (p *Float32Parser) Parse(value string, field reflect.Value) error {
+	if value == "" {
+		return nil
+	}
+	value, err := stripDigitSeparators(value)
+	if err != nil {
+		return err
+	}
+	v, err := strconv.ParseFloat(value, 32)
+	if err != nil {
+		return err
+	}
+	field.SetFloat(v)
+	return nil
+}
+
+// ParseWithTags applies percent handling when the percent tag is set
+func (p *Float32Parser) ParseWithTags(value string, field reflect.Value, tags reflect.StructTag) error {
+	if mode := tags.Get(PercentTag); mode != "" {
+		return parsePercent(value, field, mode)
+	}
+	return p.Parse(value, field)
+}
+
+// parsePercent parses a percentage between 0 and 100, with an optional "%" suffix.
+// With percent:"true" the value is stored as a ratio (50 -> 0.5); with
+// percent:"raw" it is stored unchanged.
+func parsePercent(value string, field reflect.Value, mode string) error {
+	if value == "" {
+		return nil
+	}
+	if mode != TagTrue && mode != PercentRaw {
+		return fmt.Errorf("invalid percent mode %q", mode)
+	}
+
+	v, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), field.Type().Bits())
+	if err != nil {
+		return err
+	}
+	if v < 0 || v > 100 {
+		return fmt.Errorf("%s: %v", ErrPercentOutOfRange, v)
+	}
+
+	if mode == TagTrue {
+		v /= 100
+	}
+	field.SetFloat(v)
+	return nil
+}
+
+// ComplexParser parses complex numbers into the target field type
+type ComplexParser struct{}
+
+// Parse converts a string like "1+2i" to a complex number sized to the target field
+func (p *ComplexParser) Parse(value string, field reflect.Value) error {
+	if value == "" {
+		return nil
+	}
+	v, err := strconv.ParseComplex(value, field.Type().Bits())
+	if err != nil {
+		return err
+	}
+	field.SetComplex(v)
+	return nil
+}
+
+// IPParser parses IP addresses into net.IP fields
+type IPParser struct{}
+
+// Parse converts an IPv4 or IPv6 address into a net.IP and sets it to the target field
+func (p *IPParser) Parse(value string, field reflect.Value) error {
+	if value == "" {
+		return nil
+	}
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return fmt.Errorf("invalid IP address: %q", value)
+	}
+	field.Set(reflect.ValueOf(ip))
+	return nil
+}
+
+// IPNetParser parses CIDR notation into net.IPNet fields
+type IPNetParser struct{}
+
+// Parse converts a CIDR string into a net.IPNet and sets it to the target field
+func (p *IPNetParser) Parse(value string, field reflect.Value) error {
+	if value == "" {
+		return nil
+	}
+	_, ipNet, err := net.ParseCIDR(value)
+	if err != nil {
+		return fmt.Errorf("invalid CIDR address: %q", value)
+	}
+	field.Set(reflect.ValueOf(*ipNet))
+	return nil
+}
+
+// URLParser parses URLs into url.URL fields
+type URLParser struct{}
+
+// Parse converts a string into a url.URL and sets it to the target field
+func (p *URLParser) Parse(value string, field reflect.Value) error {
+	if value == "" {
+		return nil
+	}
+	u, err := url.Parse(value)
+	if err != nil {
+		return err
+	}
+	field.Set(reflect.ValueOf(*u))
+	return nil
+}
+
+// TimeParser parses timestamps into time.Time fields
+type TimeParser struct{}
+
+// Parse converts an RFC 3339 timestamp into a time.Time and sets it to the target field
+func (p *TimeParser) Parse(value string, field reflect.Value) error {
+	return p.ParseWithTags(value, field, "")
+}
+
+// ParseWithTags converts a timestamp using the layout tag (RFC 3339 by default).
+// Layouts without a zone are interpreted in the timezone tag's location, or UTC.
+func (p *TimeParser) ParseWithTags(value string, field reflect.Value, tags reflect.StructTag) error {
+	if value == "" {
+		return nil
+	}
+
+	layout := tags.Get(LayoutTag)
+	if layout == "" {
+		layout = time.RFC3339
+	}
+
+	loc := time.UTC
+	if tz := tags.Get(TimezoneTag); tz != "" {
+		var err error
+		if loc, err = time.LoadLocation(tz); err != nil {
+			return fmt.Errorf("invalid timezone %q: %w", tz, err)
+		}
+	}
+
+	t, err := time.ParseInLocation(layout, value, loc)
+	if err != nil {
+		return err
+	}
+	field.Set(reflect.ValueOf(t))
+	return nil
+}
+
+// parseClock converts an HH:MM:SS or MM:SS duration. Hours are unbounded;
+// minutes and seconds must be below 60.
+func parseClock(value string, field reflect.Value) error {
+	if value == "" {
+		return nil
+	}
+
+	parts := strings.Split(value, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return fmt.Errorf("invalid clock duration %q: expected HH:MM:SS or MM:SS", value)
+	}
+
+	var d time.Duration
+	units := []time.Duration{time.Hour, time.Minute, time.Second}[3-len(parts):]
+	for i, part := range parts {
+		n, err := strconv.ParseUint(part, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid clock duration %q: %w", value, err)
+		}
+		if units[i] != time.Hour && n >= 60 {
+			return fmt.Errorf("invalid clock duration %q: %s out of range", value, part)
+		}
+		if n > uint64((math.MaxInt64-d)/units[i]) {
+			return fmt.Errorf("invalid clock duration %q: out of range", value)
+		}
+		d += time.Duration(n) * units[i]
+	}
+
+	field.Set(reflect.ValueOf(d))
+	return nil
+}
+
+// durationUnits lists the units accepted by the unit tag
+var durationUnits = map[string]bool{"ns": true, "us": true, "ms": true, "s": true, "m": true, "h": true}
+
+// byteSizeUnits maps lowercased size suffixes to their multipliers, SI units
+// being powers of 1000 and IEC units powers of 1024
+var byteSizeUnits = map[string]uint64{
+	"":    1,
+	"b":   1,
+	"kb":  1e3,
+	"mb":  1e6,
+	"gb":  1e9,
+	"tb":  1e12,
+	"kib": 1 << 10,
+	"mib": 1 << 20,
+	"gib": 1 << 30,
+	"tib": 1 << 40,
+}
+
+// ByteSizeParser parses sizes such as 10MB or 512KiB into a byte count
+type ByteSizeParser struct{}
+
+// Parse converts a size with an optional SI (KB, MB, GB, TB) or IEC (KiB, MiB,
+// GiB, TiB) suffix into bytes and sets it to an integer field. Suffixes are
+// case-insensitive and a bare number means bytes.
+func (p *ByteSizeParser) Parse(value string, field reflect.Value) error {
+	if value == "" {
+		return nil
+	}
+
+	rest := strings.TrimLeftFunc(value, func(r rune) bool { return r >= '0' && r <= '9' })
+	number, suffix := value[:len(value)-len(rest)], strings.TrimSpace(rest)
+	if number == "" {
+		return fmt.Errorf("invalid size %q", value)
+	}
+
+	multiplier, ok := byteSizeUnits[strings.ToLower(suffix)]
+	if !ok {
+		return fmt.Errorf("invalid size %q: unknown unit %q", value, suffix)
+	}
+
+	n, err := strconv.ParseUint(number, 10, 64)
+	if err != nil || n > math.MaxUint64/multiplier {
+		return fmt.Errorf("invalid size %q: out of range", value)
+	}
+	n *= multiplier
+
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n > math.MaxInt64 || field.OverflowInt(int64(n)) {
+			return fmt.Errorf("invalid size %q: out of range for %s", value, field.Type())
+		}
+		field.SetInt(int64(n))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if field.OverflowUint(n) {
+			return fmt.Errorf("invalid size %q: out of range for %s", value, field.Type())
+		}
+		field.SetUint(n)
+	default:
+		return fmt.Errorf(ErrUnsupportedType, field.Kind())
+	}
+	return nil
+}
+
+// BigIntParser parses arbitrary-precision integers into big.Int fields
+type BigIntParser struct{}
+
+// Parse converts a decimal string into a big.Int and sets it to the target field
+func (p *BigIntParser) Parse(value string, field reflect.Value) error {
+	return p.ParseWithTags(value, field, "")
+}
+
+// ParseWithTags converts a string in the base given by the base tag (10 by
+// default; auto or 0 accepts 0x, 0o and 0b prefixes) into a big.Int
+func (p *BigIntParser) ParseWithTags(value string, field reflect.Value, tags reflect.StructTag) error {
+	if value == "" {
+		return nil
+	}
+
+	base, err := intBase(tags, big.MaxBase)
+	if err != nil {
+		return err
+	}
+
+	n, ok := new(big.Int).SetString(value, base)
+	if !ok {
+		return fmt.Errorf("invalid base %d integer %q", base, value)
+	}
+	field.Set(reflect.ValueOf(*n))
+	return nil
+}
+
+// BigFloatParser parses arbitrary-precision floats into big.Float fields
+type BigFloatParser struct{}
+
+// Parse converts a decimal or scientific-notation string into a big.Float
+func (p *BigFloatParser) Parse(value string, field reflect.Value) error {
+	if value == "" {
+		return nil
+	}
+	f, ok := new(big.Float).SetString(value)
+	if !ok {
+		return fmt.Errorf("invalid float %q", value)
+	}
+	field.Set(reflect.ValueOf(*f))
+	return nil
+}
+
+// BytesParser parses []byte fields, decoding them according to the encoding tag
+type BytesParser struct{}
+
+// Parse copies the raw string bytes into the target field
+func (p *BytesParser) Parse(value string, field reflect.Value) error {
+	return p.ParseWithTags(value, field, "")
+}
+
+// ParseWithTags decodes the value as base64, hex, or a raw string (the default)
+func (p *BytesParser) ParseWithTags(value string, field reflect.Value, tags reflect.StructTag) error {
+	if value == "" {
+		return nil
+	}
+
+	var b []byte
+	var err error
+	switch enc := tags.Get(EncodingTag); enc {
+	case "", EncodingString:
+		b = []byte(value)
+	case EncodingBase64:
+		if b, err = base64.StdEncoding.DecodeString(value); err != nil {
+			return fmt.Errorf("invalid base64 value: %w", err)
+		}
+	case EncodingHex:
+		if b, err = hex.DecodeString(value); err != nil {
+			return fmt.Errorf("invalid hex value: %w", err)
+		}
+	default:
+		return fmt.Errorf("unsupported encoding %q", enc)
+	}
+
+	field.SetBytes(b)
+	return nil
+}
+
+// typeParsers maps concrete types that need special handling to their parsers.
+// They take precedence over the kind-based parsers.
+var typeParsers = map[reflect.Type]ValueParser{
+	reflect.TypeOf(time.Duration(0)): &DurationParser{},
+	reflect.TypeOf(time.Time{}):      &TimeParser{},
+	reflect.TypeOf(net.IP{}):         &IPParser{},
+	reflect.TypeOf(net.IPNet{}):      &IPNetParser{},
+	reflect.TypeOf(url.URL{}):        &URLParser{},
+	reflect.TypeOf([]byte{}):         &BytesParser{},
+	reflect.TypeOf(big.Int{}):        &BigIntParser{},
+	reflect.TypeOf(big.Float{}):      &BigFloatParser{},
+}
+
+// defaultParsers maps reflect.Kind to their respective ValueParser implementations.
+// It is the single source of the built-in kind parsers: every loader starts from
+// a copy, and slice, array and map elements use the same instances.
 var defaultParsers = map[reflect.Kind]ValueParser{
-	reflect.String:  &StringParser{},
-	reflect.Int64:   &Int64Parser{},
-	reflect.Int:     &IntParser{},
-	reflect.Slice:   &SliceParser{},
-	reflect.Bool:    &BoolParser{},
-	reflect.Float64: &Float64Parser{},
+	reflect.String:     &StringParser{},
+	reflect.Int64:      &Int64Parser{},
+	reflect.Int:        &IntParser{},
+	reflect.Int8:       &IntParser{},
+	reflect.Int16:      &IntParser{},
+	reflect.Int32:      &IntParser{},
+	reflect.Uint:       &UintParser{},
+	reflect.Uint8:      &UintParser{},
+	reflect.Uint16:     &UintParser{},
+	reflect.Uint32:     &UintParser{},
+	reflect.Uint64:     &UintParser{},
+	reflect.Slice:      &SliceParser{},
+	reflect.Array:      &ArrayParser{},
+	reflect.Map:        &MapParser{},
+	reflect.Bool:       &BoolParser{},
+	reflect.Float32:    &Float32Parser{},
+	reflect.Float64:    &Float64Parser{},
+	reflect.Complex64:  &ComplexParser{},
+	reflect.Complex128: &ComplexParser{},
+}
+
+// typeParserProvider looks up the parser registered for a concrete type
+type typeParserProvider func(reflect.Type) (ValueParser, bool)
+
+// elementParser returns the parser for a slice, array or map element type,
+// preferring type-specific parsers (e.g. time.Time), then types implementing
+// encoding.TextUnmarshaler, over the kind-based provider. A nil types falls
+// back to the built-in type parsers.
+func elementParser(t reflect.Type, types typeParserProvider, getParser func(reflect.Kind) (ValueParser, bool)) (ValueParser, bool) {
+	if types == nil {
+		types = builtinTypeParser
+	}
+	if p, ok := types(t); ok {
+		return p, true
+	}
+	if isTextUnmarshaler(t) {
+		return &textParser{}, true
+	}
+	return getParser(t.Kind())
+}
+
+// textParser parses elements whose type implements encoding.TextUnmarshaler
+type textParser struct{}
+
+// Parse calls UnmarshalText on the addressable target field
+func (p *textParser) Parse(value string, field reflect.Value) error {
+	return parseText(value, field)
+}
+
+// builtinTypeParser looks up element parsers in typeParsers
+func builtinTypeParser(t reflect.Type) (ValueParser, bool) {
+	p, ok := typeParsers[t]
+	return p, ok
+}
+
+// defaultParserProvider looks up element parsers in defaultParsers
+func defaultParserProvider(kind reflect.Kind) (ValueParser, bool) {
+	p, ok := defaultParsers[kind]
+	return p, ok
 }
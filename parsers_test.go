@@ -1,6 +1,8 @@
 package config
 
 import (
+	"math/big"
+	"net"
 	"os"
 	"reflect"
 	"testing"
@@ -32,6 +34,35 @@ func TestStringParser_Parse(t *testing.T) {
 	}
 }
 
+func TestStringParser_ParseWithTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		tags    reflect.StructTag
+		want    string
+		wantErr string
+	}{
+		{"no case tag", "MiXed", ``, "MiXed", ""},
+		{"lower", "MiXed", `case:"lower"`, "mixed", ""},
+		{"upper", "MiXed", `case:"upper"`, "MIXED", ""},
+		{"unknown case", "MiXed", `case:"title"`, "", `unknown case "title"`},
+	}
+
+	parser := &StringParser{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := reflect.New(reflect.TypeOf("")).Elem()
+			err := parser.ParseWithTags(tt.value, field, tt.tags)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, field.String())
+		})
+	}
+}
+
 func TestInt64Parser_Parse(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -43,6 +74,11 @@ func TestInt64Parser_Parse(t *testing.T) {
 		{"empty string", "", 0, false},
 		{"invalid number", "abc", 0, true},
 		{"negative number", "-123", -123, false},
+		{"underscore separators", "1_000_000", 1000000, false},
+		{"negative with separators", "-12_345", -12345, false},
+		{"doubled underscore", "1__0", 0, true},
+		{"leading underscore", "_100", 0, true},
+		{"trailing underscore", "100_", 0, true},
 	}
 
 	parser := &Int64Parser{}
@@ -80,6 +116,30 @@ func TestSliceParser_Parse(t *testing.T) {
 			typ:   reflect.TypeOf([]int64{}),
 			want:  []int64{1, 2, 3},
 		},
+		{
+			name:  "duration slice",
+			value: "5s,10s,1m",
+			typ:   reflect.TypeOf([]time.Duration{}),
+			want:  []time.Duration{5 * time.Second, 10 * time.Second, time.Minute},
+		},
+		{
+			name:  "bool slice",
+			value: "true,false",
+			typ:   reflect.TypeOf([]bool{}),
+			want:  []bool{true, false},
+		},
+		{
+			name:  "float64 slice",
+			value: "0.5,1.25,-3",
+			typ:   reflect.TypeOf([]float64{}),
+			want:  []float64{0.5, 1.25, -3},
+		},
+		{
+			name:    "invalid duration slice",
+			value:   "5s,soon",
+			typ:     reflect.TypeOf([]time.Duration{}),
+			wantErr: true,
+		},
 		{
 			name:    "invalid int slice",
 			value:   "1,a,3",
@@ -114,8 +174,8 @@ func TestSliceParserEdgeCases(t *testing.T) {
 
 	// Test with unsupported element type
 	t.Run("unsupported element type", func(t *testing.T) {
-		// Create a slice of an unsupported type (e.g., complex64)
-		field := reflect.New(reflect.TypeOf([]complex64{})).Elem()
+		// Create a slice of an unsupported type (e.g., chan)
+		field := reflect.New(reflect.TypeOf([]chan int{})).Elem()
 		err := parser.Parse("1,2,3", field)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "unsupported slice element type")
@@ -130,6 +190,281 @@ func TestSliceParserEdgeCases(t *testing.T) {
 	})
 }
 
+func TestSliceParser_Escaping(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []string
+		wantErr bool
+	}{
+		{"escaped delimiter", `a\,b,c`, []string{"a,b", "c"}, false},
+		{"double backslash", `a\\,b`, []string{`a\`, "b"}, false},
+		{"escaped backslash before escaped delimiter", `a\\\,b`, []string{`a\,b`}, false},
+		{"other backslashes kept", `\d+,*.go`, []string{`\d+`, "*.go"}, false},
+		{"dangling backslash", `a,b\`, nil, true},
+	}
+
+	parser := &SliceParser{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := reflect.New(reflect.TypeOf([]string{})).Elem()
+			err := parser.Parse(tt.value, field)
+			if tt.wantErr {
+				assert.ErrorContains(t, err, ErrDanglingEscape)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, field.Interface())
+			}
+		})
+	}
+}
+
+func TestSliceParser_CSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []string
+		wantErr bool
+	}{
+		{"quoted element with comma", `"a,b",c`, []string{"a,b", "c"}, false},
+		{"unquoted list", "a,b,c", []string{"a", "b", "c"}, false},
+		{"escaped quote", `"say ""hi""",x`, []string{`say "hi"`, "x"}, false},
+		{"backslash kept", `a\,b`, []string{`a\`, "b"}, false},
+		{"unterminated quote", `"a,b,c`, nil, true},
+		{"multiple lines", "a,b\nc,d", nil, true},
+	}
+
+	parser := &SliceParser{tags: `csv:"true"`}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := reflect.New(reflect.TypeOf([]string{})).Elem()
+			err := parser.Parse(tt.value, field)
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "invalid CSV list")
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, field.Interface())
+			}
+		})
+	}
+}
+
+func TestSliceParser_Dedup(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		typ   reflect.Type
+		want  interface{}
+	}{
+		{"duplicate strings", "a,b,a,c", reflect.TypeOf([]string{}), []string{"a", "b", "c"}},
+		{"no duplicates", "c,b,a", reflect.TypeOf([]string{}), []string{"c", "b", "a"}},
+		{"ints compared after parsing", "1,01,2", reflect.TypeOf([]int{}), []int{1, 2}},
+		{"durations", "1m,60s,5s", reflect.TypeOf([]time.Duration{}), []time.Duration{time.Minute, 5 * time.Second}},
+	}
+
+	parser := &SliceParser{types: builtinTypeParser, tags: `dedup:"true"`}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := reflect.New(tt.typ).Elem()
+			err := parser.Parse(tt.value, field)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, field.Interface())
+		})
+	}
+
+	t.Run("non-comparable elements", func(t *testing.T) {
+		field := reflect.New(reflect.TypeOf([][]byte{})).Elem()
+		err := parser.Parse("a,b", field)
+		assert.ErrorContains(t, err, ErrDedupNotComparable)
+	})
+}
+
+func TestArrayParser_Parse(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		typ     reflect.Type
+		want    interface{}
+		wantErr string
+	}{
+		{
+			name:  "exact count",
+			value: "a,b,c",
+			typ:   reflect.TypeOf([3]string{}),
+			want:  [3]string{"a", "b", "c"},
+		},
+		{
+			name:  "int elements",
+			value: "1,2",
+			typ:   reflect.TypeOf([2]int{}),
+			want:  [2]int{1, 2},
+		},
+		{
+			name:  "empty value",
+			value: "",
+			typ:   reflect.TypeOf([3]string{}),
+			want:  [3]string{},
+		},
+		{
+			name:    "too few elements",
+			value:   "a,b",
+			typ:     reflect.TypeOf([3]string{}),
+			wantErr: "expected 3 array elements, got 2",
+		},
+		{
+			name:    "too many elements",
+			value:   "a,b,c,d",
+			typ:     reflect.TypeOf([3]string{}),
+			wantErr: "expected 3 array elements, got 4",
+		},
+		{
+			name:    "invalid element",
+			value:   "1,x",
+			typ:     reflect.TypeOf([2]int{}),
+			wantErr: "invalid syntax",
+		},
+		{
+			name:    "unsupported element type",
+			value:   "1",
+			typ:     reflect.TypeOf([1]chan int{}),
+			wantErr: "unsupported array element type",
+		},
+	}
+
+	parser := &ArrayParser{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := reflect.New(tt.typ).Elem()
+			err := parser.Parse(tt.value, field)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, field.Interface())
+			}
+		})
+	}
+}
+
+func TestMapParser_Parse(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		typ     reflect.Type
+		tags    reflect.StructTag
+		want    interface{}
+		wantErr string
+	}{
+		{
+			name:  "string map",
+			value: "env=prod,team=core",
+			typ:   reflect.TypeOf(map[string]string{}),
+			want:  map[string]string{"env": "prod", "team": "core"},
+		},
+		{
+			name:  "int values",
+			value: "low=1,high=10",
+			typ:   reflect.TypeOf(map[string]int{}),
+			want:  map[string]int{"low": 1, "high": 10},
+		},
+		{
+			name:  "duration values",
+			value: "read=5s,write=1m",
+			typ:   reflect.TypeOf(map[string]time.Duration{}),
+			want:  map[string]time.Duration{"read": 5 * time.Second, "write": time.Minute},
+		},
+		{
+			name:  "empty value",
+			value: "",
+			typ:   reflect.TypeOf(map[string]string{}),
+			want:  map[string]string(nil),
+		},
+		{
+			name:  "value containing equals",
+			value: "query=a=b",
+			typ:   reflect.TypeOf(map[string]string{}),
+			want:  map[string]string{"query": "a=b"},
+		},
+		{
+			name:    "malformed pair",
+			value:   "env=prod,team",
+			typ:     reflect.TypeOf(map[string]string{}),
+			wantErr: `invalid map entry "team"`,
+		},
+		{
+			name:    "invalid int value",
+			value:   "low=one",
+			typ:     reflect.TypeOf(map[string]int{}),
+			wantErr: `map value for key "low"`,
+		},
+		{
+			name:    "unsupported value type",
+			value:   "a=1",
+			typ:     reflect.TypeOf(map[string]chan int{}),
+			wantErr: "unsupported map value type",
+		},
+		{
+			name:  "list values",
+			value: "a=1|2,b=3",
+			typ:   reflect.TypeOf(map[string][]string{}),
+			want:  map[string][]string{"a": {"1", "2"}, "b": {"3"}},
+		},
+		{
+			name:  "typed list values with custom delimiter",
+			value: "read=1s;5s,write=1m",
+			typ:   reflect.TypeOf(map[string][]time.Duration{}),
+			tags:  `value_delimiter:";"`,
+			want:  map[string][]time.Duration{"read": {time.Second, 5 * time.Second}, "write": {time.Minute}},
+		},
+		{
+			name:  "empty list value",
+			value: "a=",
+			typ:   reflect.TypeOf(map[string][]int{}),
+			want:  map[string][]int{"a": nil},
+		},
+		{
+			name:    "invalid list element",
+			value:   "a=1|x",
+			typ:     reflect.TypeOf(map[string][]int{}),
+			wantErr: `map value for key "a": element 1:`,
+		},
+		{
+			name:    "comma delimiter",
+			value:   "a=1",
+			typ:     reflect.TypeOf(map[string][]int{}),
+			tags:    `value_delimiter:","`,
+			wantErr: `invalid value_delimiter ","`,
+		},
+		{
+			name:    "empty key",
+			value:   "=1",
+			typ:     reflect.TypeOf(map[string]int{}),
+			wantErr: `invalid map entry "=1": empty key`,
+		},
+		{
+			name:    "unsupported list element type",
+			value:   "a=1",
+			typ:     reflect.TypeOf(map[string][]chan int{}),
+			wantErr: "unsupported slice element type: chan",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := &MapParser{tags: tt.tags}
+			field := reflect.New(tt.typ).Elem()
+			err := parser.Parse(tt.value, field)
+			if tt.wantErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, field.Interface())
+			}
+		})
+	}
+}
+
 type BoolWithDefault struct {
 	Value bool `default:"true"`
 }
@@ -138,6 +473,39 @@ type FloatWithDefault struct {
 	Value float64 `default:"1.23"`
 }
 
+func TestBoolParser_ParseWithTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		tags    reflect.StructTag
+		want    bool
+		wantErr string
+	}{
+		{"one without flag", "1", ``, true, ""},
+		{"yes without flag", "yes", ``, true, ""},
+		{"true literal", "true", `strict_bool:"true"`, true, ""},
+		{"false upper case", "FALSE", `strict_bool:"true"`, false, ""},
+		{"one rejected", "1", `strict_bool:"true"`, false, `expected true or false, got "1"`},
+		{"zero rejected", "0", `strict_bool:"true"`, false, `expected true or false, got "0"`},
+		{"yes rejected", "yes", `strict_bool:"true"`, false, `expected true or false, got "yes"`},
+		{"flag disabled", "1", `strict_bool:"false"`, true, ""},
+	}
+
+	parser := &BoolParser{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := reflect.New(reflect.TypeOf(false)).Elem()
+			err := parser.ParseWithTags(tt.value, field, tt.tags)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, field.Bool())
+		})
+	}
+}
+
 func TestBoolParser_Parse(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -148,6 +516,14 @@ func TestBoolParser_Parse(t *testing.T) {
 		{"true value", "true", true, false},
 		{"false value", "false", false, false},
 		{"invalid value", "invalid", false, true},
+		{"one", "1", true, false},
+		{"zero", "0", false, false},
+		{"yes", "yes", true, false},
+		{"no", "no", false, false},
+		{"on upper case", "ON", true, false},
+		{"off mixed case", "Off", false, false},
+		{"enabled rejected", "ENABLED", false, true},
+		{"maybe rejected", "maybe", false, true},
 	}
 
 	parser := &BoolParser{}
@@ -193,6 +569,9 @@ func TestFloat64Parser_Parse(t *testing.T) {
 		{"valid float", "3.14", 3.14, false},
 		{"integer float", "42", 42.0, false},
 		{"invalid float", "not-a-float", 0, true},
+		{"underscore separators", "1_000.000_5", 1000.0005, false},
+		{"underscore next to point", "1_.5", 0, true},
+		{"doubled underscore", "1__0.5", 0, true},
 	}
 
 	parser := &Float64Parser{}
@@ -227,6 +606,35 @@ func TestFloat64Parser_Parse(t *testing.T) {
 	})
 }
 
+func TestFloat32Parser_Parse(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    float32
+		wantErr bool
+	}{
+		{"valid float", "1.5", 1.5, false},
+		{"empty string", "", 0, false},
+		{"precision truncated", "3.14159265358979", float32(3.14159265358979), false},
+		{"overflow", "1e39", 0, true},
+		{"invalid float", "not-a-float", 0, true},
+	}
+
+	parser := &Float32Parser{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := reflect.New(reflect.TypeOf(float32(0))).Elem()
+			err := parser.Parse(tt.value, field)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, field.Interface())
+			}
+		})
+	}
+}
+
 func TestIntParser_Parse(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -240,6 +648,8 @@ func TestIntParser_Parse(t *testing.T) {
 		{"negative number", "-123", -123, false},
 		{"zero", "0", 0, false},
 		{"large number", "2147483647", 2147483647, false},
+		{"underscore separators", "1_000_000", 1000000, false},
+		{"doubled underscore", "1__0", 0, true},
 	}
 
 	parser := &IntParser{}
@@ -257,6 +667,115 @@ func TestIntParser_Parse(t *testing.T) {
 	}
 }
 
+func TestIntParser_ParseWidths(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		typ     reflect.Type
+		want    int64
+		wantErr bool
+	}{
+		{"int8 max", "127", reflect.TypeOf(int8(0)), 127, false},
+		{"int8 min", "-128", reflect.TypeOf(int8(0)), -128, false},
+		{"int8 overflow", "200", reflect.TypeOf(int8(0)), 0, true},
+		{"int16 max", "32767", reflect.TypeOf(int16(0)), 32767, false},
+		{"int16 overflow", "32768", reflect.TypeOf(int16(0)), 0, true},
+		{"int32 max", "2147483647", reflect.TypeOf(int32(0)), 2147483647, false},
+		{"int32 min", "-2147483648", reflect.TypeOf(int32(0)), -2147483648, false},
+		{"int32 overflow", "2147483648", reflect.TypeOf(int32(0)), 0, true},
+		{"int32 underflow", "-2147483649", reflect.TypeOf(int32(0)), 0, true},
+		{"int64 via int parser", "9223372036854775807", reflect.TypeOf(int64(0)), 9223372036854775807, false},
+	}
+
+	parser := &IntParser{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := reflect.New(tt.typ).Elem()
+			err := parser.Parse(tt.value, field)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "out of range")
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, field.Int())
+			}
+		})
+	}
+}
+
+func TestUintParser_Parse(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		typ     reflect.Type
+		want    uint64
+		wantErr bool
+	}{
+		{"valid uint", "123", reflect.TypeOf(uint(0)), 123, false},
+		{"empty string", "", reflect.TypeOf(uint(0)), 0, false},
+		{"invalid number", "abc", reflect.TypeOf(uint(0)), 0, true},
+		{"negative number", "-1", reflect.TypeOf(uint(0)), 0, true},
+		{"uint8 max", "255", reflect.TypeOf(uint8(0)), 255, false},
+		{"uint8 overflow", "300", reflect.TypeOf(uint8(0)), 0, true},
+		{"uint16 overflow", "65536", reflect.TypeOf(uint16(0)), 0, true},
+		{"uint32 max", "4294967295", reflect.TypeOf(uint32(0)), 4294967295, false},
+		{"uint32 overflow", "4294967296", reflect.TypeOf(uint32(0)), 0, true},
+		{"uint64 max", "18446744073709551615", reflect.TypeOf(uint64(0)), 18446744073709551615, false},
+	}
+
+	parser := &UintParser{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := reflect.New(tt.typ).Elem()
+			err := parser.Parse(tt.value, field)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, field.Uint())
+			}
+		})
+	}
+}
+
+func TestIntParsers_Base(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		tags    reflect.StructTag
+		typ     reflect.Type
+		want    interface{}
+		wantErr bool
+	}{
+		{"auto hex", "0xFF", `base:"auto"`, reflect.TypeOf(0), 255, false},
+		{"auto octal", "0o755", `base:"auto"`, reflect.TypeOf(uint32(0)), uint32(0o755), false},
+		{"auto leading zero octal", "0755", `base:"auto"`, reflect.TypeOf(int64(0)), int64(0o755), false},
+		{"auto binary", "0b1010", `base:"auto"`, reflect.TypeOf(int8(0)), int8(10), false},
+		{"auto decimal", "42", `base:"auto"`, reflect.TypeOf(0), 42, false},
+		{"explicit base", "ff", `base:"16"`, reflect.TypeOf(uint8(0)), uint8(255), false},
+		{"default is decimal", "0755", ``, reflect.TypeOf(0), 755, false},
+		{"prefix rejected by default", "0xFF", ``, reflect.TypeOf(0), nil, true},
+		{"overflow", "0x1FF", `base:"auto"`, reflect.TypeOf(uint8(0)), nil, true},
+		{"invalid base", "10", `base:"hex"`, reflect.TypeOf(0), nil, true},
+	}
+
+	loader := NewEnvLoader()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := reflect.New(tt.typ).Elem()
+			parser, ok := loader.getParserForType(tt.typ.Kind())
+			assert.True(t, ok)
+			err := parseValue(parser, tt.value, field, tt.tags)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, field.Interface())
+			}
+		})
+	}
+}
+
 func TestDurationParser_Parse(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -269,6 +788,8 @@ func TestDurationParser_Parse(t *testing.T) {
 		{"empty string", "", 0, false},
 		{"invalid duration", "invalid", 0, true},
 		{"negative duration", "-10s", -10 * time.Second, false},
+		{"negative duration without unit", "-5", -5 * time.Second, false},
+		{"fractional without unit", "1.5", 0, true},
 	}
 
 	parser := &DurationParser{}
@@ -286,6 +807,351 @@ func TestDurationParser_Parse(t *testing.T) {
 	}
 }
 
+func TestDurationParser_ParseWithTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		tags    reflect.StructTag
+		want    time.Duration
+		wantErr string
+	}{
+		{"milliseconds", "500", `unit:"ms"`, 500 * time.Millisecond, ""},
+		{"minutes", "15", `unit:"m"`, 15 * time.Minute, ""},
+		{"microseconds", "250", `unit:"us"`, 250 * time.Microsecond, ""},
+		{"explicit unit ignores tag", "2s", `unit:"ms"`, 2 * time.Second, ""},
+		{"default unit is seconds", "30", "", 30 * time.Second, ""},
+		{"unknown unit", "500", `unit:"days"`, 0, ErrUnknownUnit},
+		{"unknown unit with empty value", "", `unit:"d"`, 0, ErrUnknownUnit},
+		{"clock hours", "01:30:00", `format:"clock"`, 90 * time.Minute, ""},
+		{"clock minutes", "05:00", `format:"clock"`, 5 * time.Minute, ""},
+		{"clock long hours", "100:00:01", `format:"clock"`, 100*time.Hour + time.Second, ""},
+		{"clock minutes out of range", "00:99:00", `format:"clock"`, 0, "out of range"},
+		{"clock hours overflow", "3000000:00:00", `format:"clock"`, 0, "out of range"},
+		{"clock seconds overflow", "2562047:47:17", `format:"clock"`, 0, "out of range"},
+		{"clock max duration", "2562047:47:16", `format:"clock"`, 2562047*time.Hour + 47*time.Minute + 16*time.Second, ""},
+		{"clock too many parts", "1:2:3:4", `format:"clock"`, 0, "expected HH:MM:SS or MM:SS"},
+		{"clock not a number", "1:xx", `format:"clock"`, 0, "invalid clock duration"},
+		{"clock rejects go syntax", "90m", `format:"clock"`, 0, "expected HH:MM:SS or MM:SS"},
+	}
+
+	parser := &DurationParser{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := reflect.New(reflect.TypeOf(time.Duration(0))).Elem()
+			err := parser.ParseWithTags(tt.value, field, tt.tags)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, field.Interface())
+			}
+		})
+	}
+}
+
+func TestBytesParser_ParseWithTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		tag     string
+		want    []byte
+		wantErr string
+	}{
+		{"base64", "c2VjcmV0LGtleQ==", `encoding:"base64"`, []byte("secret,key"), ""},
+		{"hex", "deadbeef", `encoding:"hex"`, []byte{0xde, 0xad, 0xbe, 0xef}, ""},
+		{"raw string", "a,b", `encoding:"string"`, []byte("a,b"), ""},
+		{"no encoding tag", "a,b", ``, []byte("a,b"), ""},
+		{"empty value", "", `encoding:"base64"`, nil, ""},
+		{"invalid base64", "not base64!", `encoding:"base64"`, nil, "invalid base64 value"},
+		{"invalid hex", "xyz", `encoding:"hex"`, nil, "invalid hex value"},
+		{"unknown encoding", "abc", `encoding:"rot13"`, nil, `unsupported encoding "rot13"`},
+	}
+
+	parser := &BytesParser{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := reflect.New(reflect.TypeOf([]byte{})).Elem()
+			err := parser.ParseWithTags(tt.value, field, reflect.StructTag(tt.tag))
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, field.Bytes())
+			}
+		})
+	}
+}
+
+func TestJSONParser_Parse(t *testing.T) {
+	type backend struct {
+		Host string `json:"host"`
+		Port int    `json:"port"`
+	}
+
+	parser := &JSONParser{}
+
+	t.Run("slice of structs", func(t *testing.T) {
+		field := reflect.New(reflect.TypeOf([]backend{})).Elem()
+		err := parser.Parse(`[{"host":"a","port":1},{"host":"b","port":2}]`, field)
+		assert.NoError(t, err)
+		assert.Equal(t, []backend{{"a", 1}, {"b", 2}}, field.Interface())
+	})
+
+	t.Run("empty value", func(t *testing.T) {
+		field := reflect.New(reflect.TypeOf([]backend{})).Elem()
+		assert.NoError(t, parser.Parse("", field))
+		assert.True(t, field.IsNil())
+	})
+
+	t.Run("malformed JSON", func(t *testing.T) {
+		field := reflect.New(reflect.TypeOf([]backend{})).Elem()
+		err := parser.Parse(`[{"host":`, field)
+		assert.ErrorContains(t, err, "invalid JSON")
+	})
+}
+
+func TestFloatParser_Percent(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		tag     string
+		want    float64
+		wantErr string
+	}{
+		{"ratio", "50", `percent:"true"`, 0.5, ""},
+		{"ratio with percent sign", "25%", `percent:"true"`, 0.25, ""},
+		{"ratio upper bound", "100", `percent:"true"`, 1, ""},
+		{"raw", "50", `percent:"raw"`, 50, ""},
+		{"above 100", "150", `percent:"true"`, 0, ErrPercentOutOfRange},
+		{"raw above 100", "150", `percent:"raw"`, 0, ErrPercentOutOfRange},
+		{"negative", "-1", `percent:"true"`, 0, ErrPercentOutOfRange},
+		{"invalid mode", "50", `percent:"yes"`, 0, `invalid percent mode "yes"`},
+		{"no tag", "50", ``, 50, ""},
+	}
+
+	parser := &Float64Parser{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := reflect.New(reflect.TypeOf(float64(0))).Elem()
+			err := parser.ParseWithTags(tt.value, field, reflect.StructTag(tt.tag))
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, field.Float())
+			}
+		})
+	}
+
+	t.Run("float32 field", func(t *testing.T) {
+		field := reflect.New(reflect.TypeOf(float32(0))).Elem()
+		err := (&Float32Parser{}).ParseWithTags("50", field, `percent:"true"`)
+		assert.NoError(t, err)
+		assert.Equal(t, float32(0.5), field.Interface())
+	})
+}
+
+func TestComplexParser_Parse(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    complex128
+		wantErr bool
+	}{
+		{"positive imaginary", "1+2i", complex(1, 2), false},
+		{"negative imaginary", "3.5-4i", complex(3.5, -4), false},
+		{"real only", "7", complex(7, 0), false},
+		{"parenthesized", "(1+2i)", complex(1, 2), false},
+		{"empty string", "", 0, false},
+		{"invalid format", "1+2j", 0, true},
+	}
+
+	parser := &ComplexParser{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := reflect.New(reflect.TypeOf(complex128(0))).Elem()
+			err := parser.Parse(tt.value, field)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, field.Complex())
+			}
+		})
+	}
+}
+
+func TestIPParser_Parse(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    net.IP
+		wantErr bool
+	}{
+		{"ipv4", "192.168.1.10", net.ParseIP("192.168.1.10"), false},
+		{"ipv6", "2001:db8::1", net.ParseIP("2001:db8::1"), false},
+		{"empty string", "", nil, false},
+		{"invalid address", "999.1.1.1", nil, true},
+		{"cidr rejected", "10.0.0.0/8", nil, true},
+	}
+
+	parser := &IPParser{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := reflect.New(reflect.TypeOf(net.IP{})).Elem()
+			err := parser.Parse(tt.value, field)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "invalid IP address")
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, field.Interface())
+			}
+		})
+	}
+}
+
+func TestIPNetParser_Parse(t *testing.T) {
+	parser := &IPNetParser{}
+
+	t.Run("cidr", func(t *testing.T) {
+		field := reflect.New(reflect.TypeOf(net.IPNet{})).Elem()
+		err := parser.Parse("10.0.0.0/8", field)
+		assert.NoError(t, err)
+		ipNet := field.Interface().(net.IPNet)
+		assert.Equal(t, "10.0.0.0/8", ipNet.String())
+	})
+
+	t.Run("invalid cidr", func(t *testing.T) {
+		field := reflect.New(reflect.TypeOf(net.IPNet{})).Elem()
+		err := parser.Parse("10.0.0.0", field)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid CIDR address")
+	})
+}
+
+func TestTimeParser_ParseWithTags(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		value   string
+		tags    reflect.StructTag
+		want    time.Time
+		wantErr bool
+	}{
+		{"rfc3339", "2024-03-01T10:30:00Z", "", time.Date(2024, 3, 1, 10, 30, 0, 0, time.UTC), false},
+		{"rfc3339 with offset", "2024-03-01T10:30:00+02:00", "", time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC), false},
+		{"custom layout", "2024-03-01", `layout:"2006-01-02"`, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), false},
+		{"custom layout with timezone", "2024-03-01 10:30", `layout:"2006-01-02 15:04" timezone:"Europe/Berlin"`, time.Date(2024, 3, 1, 10, 30, 0, 0, berlin), false},
+		{"empty string", "", "", time.Time{}, false},
+		{"invalid date", "not-a-date", "", time.Time{}, true},
+		{"layout mismatch", "2024-03-01", "", time.Time{}, true},
+		{"invalid timezone", "2024-03-01", `layout:"2006-01-02" timezone:"Mars/Olympus"`, time.Time{}, true},
+	}
+
+	parser := &TimeParser{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := reflect.New(reflect.TypeOf(time.Time{})).Elem()
+			err := parser.ParseWithTags(tt.value, field, tt.tags)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, tt.want.Equal(field.Interface().(time.Time)), "got %v", field.Interface())
+			}
+		})
+	}
+}
+
+func TestByteSizeParser_Parse(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		typ     reflect.Type
+		want    interface{}
+		wantErr bool
+	}{
+		{"si megabytes", "10MB", reflect.TypeOf(int64(0)), int64(10_000_000), false},
+		{"iec gibibytes", "1GiB", reflect.TypeOf(int64(0)), int64(1 << 30), false},
+		{"bare bytes", "1024", reflect.TypeOf(int64(0)), int64(1024), false},
+		{"lowercase with space", "512 kib", reflect.TypeOf(0), 512 * 1024, false},
+		{"unsigned", "2TB", reflect.TypeOf(uint64(0)), uint64(2e12), false},
+		{"empty string", "", reflect.TypeOf(int64(0)), int64(0), false},
+		{"invalid suffix", "10XB", reflect.TypeOf(int64(0)), nil, true},
+		{"missing number", "MB", reflect.TypeOf(int64(0)), nil, true},
+		{"negative", "-1MB", reflect.TypeOf(int64(0)), nil, true},
+		{"overflows field", "1GB", reflect.TypeOf(int16(0)), nil, true},
+		{"overflows uint64", "100000000TiB", reflect.TypeOf(uint64(0)), nil, true},
+	}
+
+	parser := &ByteSizeParser{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := reflect.New(tt.typ).Elem()
+			err := parser.Parse(tt.value, field)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, field.Interface())
+			}
+		})
+	}
+
+	t.Run("invalid suffix message", func(t *testing.T) {
+		err := parser.Parse("10XB", reflect.New(reflect.TypeOf(int64(0))).Elem())
+		assert.EqualError(t, err, `invalid size "10XB": unknown unit "XB"`)
+	})
+}
+
+func TestBigIntParser_ParseWithTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		tags    reflect.StructTag
+		want    string
+		wantErr bool
+	}{
+		{"large decimal", "123456789012345678901234567890", "", "123456789012345678901234567890", false},
+		{"negative", "-42", "", "-42", false},
+		{"hex", "ffffffffffffffffffff", `base:"16"`, "1208925819614629174706175", false},
+		{"prefixed with base 0", "0x10", `base:"0"`, "16", false},
+		{"invalid value", "12abc", "", "", true},
+		{"hex digits in base 10", "ff", "", "", true},
+		{"invalid base", "10", `base:"one"`, "", true},
+		{"base out of range", "10", `base:"63"`, "", true},
+	}
+
+	parser := &BigIntParser{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := reflect.New(reflect.TypeOf(big.Int{})).Elem()
+			err := parser.ParseWithTags(tt.value, field, tt.tags)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				n := field.Addr().Interface().(*big.Int)
+				assert.Equal(t, tt.want, n.String())
+			}
+		})
+	}
+}
+
+func TestBigFloatParser_Parse(t *testing.T) {
+	parser := &BigFloatParser{}
+
+	field := reflect.New(reflect.TypeOf(big.Float{})).Elem()
+	assert.NoError(t, parser.Parse("1.5e100", field))
+	f := field.Addr().Interface().(*big.Float)
+	assert.Equal(t, "1.5e+100", f.Text('g', 10))
+
+	assert.Error(t, parser.Parse("1.5.0", field))
+}
+
 func TestDefaultValues(t *testing.T) {
 	type DefaultStruct struct {
 		String string  `env:"TEST_STRING" default:"default-string"`
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"reflect"
+)
+
+// ValueOrigin identifies where a loaded value came from
+type ValueOrigin string
+
+// Value origins reported by LoadConfigWithReport
+const (
+	OriginEnv     ValueOrigin = "env"     // the process environment
+	OriginFile    ValueOrigin = "file"    // a .env file or reader
+	OriginSource  ValueOrigin = "source"  // another source, such as a map or one added with WithSource
+	OriginDefault ValueOrigin = "default" // WithDefaults, a Defaults method, or the default tag
+	OriginUnset   ValueOrigin = "unset"   // no value and no default
+)
+
+// FieldReport describes how one field's value was resolved
+type FieldReport struct {
+	Path   string // dotted Go field path, e.g. Database.Host
+	EnvKey string // full env name including prefixes
+	Value  string // resolved raw value, masked for secret fields
+	Origin ValueOrigin
+}
+
+// LoadReport lists the resolution of every loaded field in load order
+type LoadReport struct {
+	Fields []FieldReport
+}
+
+// LoadConfigWithReport loads cfg using the default loader and reports where each value came from
+func LoadConfigWithReport(cfg interface{}) (*LoadReport, error) {
+	return defaultLoader.LoadConfigWithReport(cfg)
+}
+
+// LoadConfigWithReport loads cfg like LoadConfig and returns a report of each
+// field's env name, resolved value, and origin, for logging at startup. Values
+// of fields tagged secret:"true" are masked. When loading fails the report
+// covers the fields processed before the failure.
+func (l *EnvLoader) LoadConfigWithReport(cfg interface{}) (*LoadReport, error) {
+	report := &LoadReport{}
+	err := l.load(cfg, &loadState{sources: l.callSources(), report: report})
+	return report, err
+}
+
+// add records a resolved field; it does nothing on a nil report
+func (r *LoadReport) add(path, envKey, value string, origin ValueOrigin, fieldType reflect.StructField) {
+	if r == nil {
+		return
+	}
+	if fieldType.Tag.Get(SecretTag) == TagTrue && value != "" {
+		value = maskedValue
+	}
+	r.Fields = append(r.Fields, FieldReport{Path: path, EnvKey: envKey, Value: value, Origin: origin})
+}
+
+// sourceOrigin classifies the source that supplied a value
+func sourceOrigin(source Source) ValueOrigin {
+	switch source.(type) {
+	case *EnvSource, *foldedEnvSource:
+		return OriginEnv
+	case dotEnvSource:
+		return OriginFile
+	default:
+		return OriginSource
+	}
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfigWithReport(t *testing.T) {
+	type ReportDatabase struct {
+		Host     string `env:"HOST"`
+		Password string `env:"PASSWORD" secret:"true"`
+	}
+	type ReportConfig struct {
+		Port     int            `env:"PORT" default:"8080"`
+		Name     string         `env:"NAME"`
+		Debug    bool           `env:"DEBUG"`
+		Database ReportDatabase `envPrefix:"DB_"`
+	}
+
+	os.Setenv("REPORT_NAME", "api")
+	os.Setenv("REPORT_DB_PASSWORD", "hunter2")
+	defer os.Unsetenv("REPORT_NAME")
+	defer os.Unsetenv("REPORT_DB_PASSWORD")
+
+	loader := NewEnvLoader(
+		WithPrefix("REPORT_"),
+		WithSource(&EnvSource{}),
+		WithSource(MapSource{"REPORT_DB_HOST": "db.local"}),
+	)
+
+	cfg := &ReportConfig{}
+	report, err := loader.LoadConfigWithReport(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, 8080, cfg.Port)
+	assert.Equal(t, []FieldReport{
+		{Path: "Port", EnvKey: "REPORT_PORT", Value: "8080", Origin: OriginDefault},
+		{Path: "Name", EnvKey: "REPORT_NAME", Value: "api", Origin: OriginEnv},
+		{Path: "Debug", EnvKey: "REPORT_DEBUG", Value: "", Origin: OriginUnset},
+		{Path: "Database.Host", EnvKey: "REPORT_DB_HOST", Value: "db.local", Origin: OriginSource},
+		{Path: "Database.Password", EnvKey: "REPORT_DB_PASSWORD", Value: maskedValue, Origin: OriginEnv},
+	}, report.Fields)
+}
+
+func TestLoadConfigWithReport_Error(t *testing.T) {
+	type ReportConfig struct {
+		Port int    `env:"REPORT_ERR_PORT"`
+		Name string `env:"REPORT_ERR_NAME"`
+	}
+
+	os.Setenv("REPORT_ERR_PORT", "abc")
+	defer os.Unsetenv("REPORT_ERR_PORT")
+
+	report, err := NewEnvLoader().LoadConfigWithReport(&ReportConfig{})
+	assert.Error(t, err)
+	assert.Equal(t, []FieldReport{
+		{Path: "Port", EnvKey: "REPORT_ERR_PORT", Value: "abc", Origin: OriginEnv},
+	}, report.Fields)
+}
+
+func TestSourceOrigin(t *testing.T) {
+	assert.Equal(t, OriginEnv, sourceOrigin(&EnvSource{}))
+	assert.Equal(t, OriginEnv, sourceOrigin(newFoldedEnvSource()))
+	assert.Equal(t, OriginFile, sourceOrigin(dotEnvSource{MapSource{}}))
+	assert.Equal(t, OriginSource, sourceOrigin(MapSource{}))
+}
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"context"
+	"os"
+	"strings"
+)
+
+// Source provides raw configuration values by key
+type Source interface {
+	Lookup(key string) (string, bool)
+}
+
+// ContextSource is implemented by sources whose lookups can block, such as remote
+// secret stores. When a source added with WithSource implements ContextSource,
+// LookupContext is used instead of Lookup and receives the context passed to
+// LoadConfigContext. A returned error aborts the load.
+type ContextSource interface {
+	LookupContext(ctx context.Context, key string) (string, bool, error)
+}
+
+// EnvSource looks up values in the process environment
+type EnvSource struct{}
+
+// Lookup returns the value of the environment variable named by key
+func (s *EnvSource) Lookup(key string) (string, bool) {
+	return os.LookupEnv(key)
+}
+
+// MapSource looks up values in a map
+type MapSource map[string]string
+
+// Lookup returns the value stored under key
+func (s MapSource) Lookup(key string) (string, bool) {
+	value, ok := s[key]
+	return value, ok
+}
+
+// WithSource adds a value source. Sources are consulted in the order they were
+// added and the first one that has a key wins. Without any WithSource option the
+// loader reads the process environment.
+func WithSource(source Source) Option {
+	return func(l *EnvLoader) {
+		l.sources = append(l.sources, source)
+	}
+}
+
+// callSources returns a fresh list of sources for a single load. With
+// case-insensitive matching every EnvSource is replaced by an index of the
+// environment built once for the call.
+func (l *EnvLoader) callSources() []Source {
+	if len(l.sources) == 0 {
+		return []Source{l.envSource()}
+	}
+
+	sources := make([]Source, len(l.sources))
+	for i, source := range l.sources {
+		if _, ok := source.(*EnvSource); ok {
+			source = l.envSource()
+		}
+		sources[i] = source
+	}
+	return sources
+}
+
+// envSource returns the source used to read the process environment
+func (l *EnvLoader) envSource() Source {
+	if l.foldCase {
+		return newFoldedEnvSource()
+	}
+	return &EnvSource{}
+}
+
+// foldedEnvSource looks up environment variables, falling back to a
+// case-insensitive match when no variable has the exact name
+type foldedEnvSource struct {
+	folded map[string]string
+}
+
+// newFoldedEnvSource indexes the current environment by lowercased name
+func newFoldedEnvSource() *foldedEnvSource {
+	folded := make(map[string]string)
+	for _, kv := range os.Environ() {
+		key, value, _ := strings.Cut(kv, "=")
+		lower := strings.ToLower(key)
+		if _, exists := folded[lower]; !exists {
+			folded[lower] = value
+		}
+	}
+	return &foldedEnvSource{folded: folded}
+}
+
+// Lookup prefers an exact match and falls back to a case-insensitive one
+func (s *foldedEnvSource) Lookup(key string) (string, bool) {
+	if value, ok := os.LookupEnv(key); ok {
+		return value, true
+	}
+	value, ok := s.folded[strings.ToLower(key)]
+	return value, ok
+}
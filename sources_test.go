@@ -0,0 +1,116 @@
+package config
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapSource_Lookup(t *testing.T) {
+	source := MapSource{"PORT": "8080", "EMPTY": ""}
+
+	value, ok := source.Lookup("PORT")
+	assert.True(t, ok)
+	assert.Equal(t, "8080", value)
+
+	value, ok = source.Lookup("EMPTY")
+	assert.True(t, ok)
+	assert.Empty(t, value)
+
+	_, ok = source.Lookup("MISSING")
+	assert.False(t, ok)
+}
+
+func TestEnvSource_Lookup(t *testing.T) {
+	os.Setenv("SOURCE_PORT", "8080")
+	defer os.Unsetenv("SOURCE_PORT")
+
+	value, ok := (&EnvSource{}).Lookup("SOURCE_PORT")
+	assert.True(t, ok)
+	assert.Equal(t, "8080", value)
+
+	_, ok = (&EnvSource{}).Lookup("SOURCE_MISSING")
+	assert.False(t, ok)
+}
+
+func TestWithSource(t *testing.T) {
+	type SourceConfig struct {
+		Host string `env:"SOURCE_HOST"`
+		Port int    `env:"SOURCE_PORT"`
+		Name string `env:"SOURCE_NAME" default:"app"`
+	}
+
+	os.Setenv("SOURCE_HOST", "env-host")
+	os.Setenv("SOURCE_PORT", "8080")
+	defer os.Unsetenv("SOURCE_HOST")
+	defer os.Unsetenv("SOURCE_PORT")
+
+	loader := NewEnvLoader(
+		WithSource(MapSource{"SOURCE_HOST": "vault-host"}),
+		WithSource(&EnvSource{}),
+	)
+
+	cfg := &SourceConfig{}
+	err := loader.LoadConfig(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, "vault-host", cfg.Host) // first source wins
+	assert.Equal(t, 8080, cfg.Port)         // falls through to the env source
+	assert.Equal(t, "app", cfg.Name)        // default when no source has the key
+
+	// Without an env source the environment is not consulted
+	mapOnly := &SourceConfig{}
+	err = NewEnvLoader(WithSource(MapSource{})).LoadConfig(mapOnly)
+	assert.NoError(t, err)
+	assert.Empty(t, mapOnly.Host)
+}
+
+// remoteSource is a ContextSource standing in for a remote secret store
+type remoteSource struct {
+	values MapSource
+}
+
+func (r *remoteSource) Lookup(key string) (string, bool) {
+	return r.values.Lookup(key)
+}
+
+func (r *remoteSource) LookupContext(ctx context.Context, key string) (string, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return "", false, err
+	}
+	value, ok := r.values.Lookup(key)
+	return value, ok, nil
+}
+
+func TestLoadConfigContext(t *testing.T) {
+	type RemoteConfig struct {
+		Host string `env:"HOST"`
+		Port int    `env:"PORT" default:"80"`
+	}
+
+	loader := NewEnvLoader(
+		WithSource(&remoteSource{values: MapSource{"HOST": "db.internal"}}),
+		WithErrorAggregation(),
+	)
+
+	cfg := &RemoteConfig{}
+	err := loader.LoadConfigContext(context.Background(), cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, "db.internal", cfg.Host)
+	assert.Equal(t, 80, cfg.Port)
+
+	// A canceled context aborts the load with the source's error
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	canceled := &RemoteConfig{}
+	err = loader.LoadConfigContext(ctx, canceled)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Contains(t, err.Error(), "lookup HOST")
+	assert.Empty(t, canceled.Host)
+
+	// LoadConfig uses a background context
+	err = loader.LoadConfig(&RemoteConfig{})
+	assert.NoError(t, err)
+}
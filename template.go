@@ -0,0 +1,58 @@
+package config
+
+import (
+	"strings"
+)
+
+// GenerateEnvTemplate returns a sample .env file for cfg using the default loader
+func GenerateEnvTemplate(cfg interface{}) string {
+	return defaultLoader.GenerateEnvTemplate(cfg)
+}
+
+// GenerateEnvTemplate returns a commented .env skeleton listing every variable cfg
+// expects. Each entry is preceded by a comment noting whether it is required or
+// has a default, whether it is secret, and its description. Defaults are used as
+// placeholder values; other variables are left empty. Entries for the elements of
+// an empty indexed struct slice, such as BACKEND_<n>_HOST, are commented out.
+func (l *EnvLoader) GenerateEnvTemplate(cfg interface{}) string {
+	var b strings.Builder
+	for i, f := range l.Describe(cfg) {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+
+		var notes []string
+		switch {
+		case f.Required:
+			notes = append(notes, "required")
+		case f.Default != "":
+			notes = append(notes, "default: "+f.Default)
+		default:
+			notes = append(notes, "optional")
+		}
+		if f.Secret {
+			notes = append(notes, "secret")
+		}
+
+		comment := strings.Join(notes, ", ")
+		if f.Description != "" {
+			comment += " - " + f.Description
+		}
+
+		b.WriteString("# " + comment + "\n")
+		if strings.Contains(f.EnvKey, indexPlaceholder) {
+			b.WriteString("# ")
+		}
+		b.WriteString(f.EnvKey + "=" + quoteDotEnvValue(f.Default) + "\n")
+	}
+	return b.String()
+}
+
+// quoteDotEnvValue double-quotes a value when it would not survive parseDotEnv unquoted
+func quoteDotEnvValue(value string) string {
+	if !strings.ContainsAny(value, " \t\n#\"'\\") {
+		return value
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + r.Replace(value) + `"`
+}
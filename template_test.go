@@ -0,0 +1,55 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateEnvTemplate(t *testing.T) {
+	type DatabaseConfig struct {
+		URL      string `env:"URL" required:"true" desc:"connection string"`
+		Password string `env:"PASSWORD" secret:"true"`
+	}
+	type TemplateConfig struct {
+		Port     int            `env:"PORT" default:"8080"`
+		Greeting string         `env:"GREETING" default:"hello world"`
+		Database DatabaseConfig `envPrefix:"DB_"`
+	}
+
+	template := NewEnvLoader().GenerateEnvTemplate(&TemplateConfig{})
+	assert.Equal(t, strings.Join([]string{
+		"# default: 8080",
+		"PORT=8080",
+		"",
+		"# default: hello world",
+		`GREETING="hello world"`,
+		"",
+		"# required - connection string",
+		"DB_URL=",
+		"",
+		"# optional, secret",
+		"DB_PASSWORD=",
+		"",
+	}, "\n"), template)
+
+	// The template is a valid .env file
+	values, err := parseDotEnv(strings.NewReader(template))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"PORT":        "8080",
+		"GREETING":    "hello world",
+		"DB_URL":      "",
+		"DB_PASSWORD": "",
+	}, values)
+}
+
+func Test_quoteDotEnvValue(t *testing.T) {
+	for _, value := range []string{"", "plain", "two words", `a"b`, `back\slash`, "a #b", "line\nbreak"} {
+		quoted := quoteDotEnvValue(value)
+		got, err := parseDotEnvValue(quoted)
+		assert.NoError(t, err)
+		assert.Equal(t, value, got, "round trip of %q via %s", value, quoted)
+	}
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// transforms are the built-in functions selectable with the transform tag
+var transforms = map[string]func(string) string{
+	"lower":     strings.ToLower,
+	"upper":     strings.ToUpper,
+	"trim":      strings.TrimSpace,
+	"trimspace": strings.TrimSpace,
+}
+
+// applyTransforms runs the comma-separated transforms named by the field's
+// transform tag on the raw value, in order, e.g. transform:"trim,lower"
+func applyTransforms(value string, fieldType reflect.StructField) (string, error) {
+	tag := fieldType.Tag.Get(TransformTag)
+	if tag == "" {
+		return value, nil
+	}
+	for _, name := range strings.Split(tag, ",") {
+		fn, ok := transforms[strings.TrimSpace(name)]
+		if !ok {
+			return "", fmt.Errorf("%s %q", ErrUnknownTransform, name)
+		}
+		value = fn(value)
+	}
+	return value, nil
+}
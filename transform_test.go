@@ -0,0 +1,37 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransformTag(t *testing.T) {
+	type TransformConfig struct {
+		Level   string `env:"LEVEL" transform:"lower" oneof:"debug info warn"`
+		Region  string `env:"REGION" transform:"trim,upper"`
+		Workers int    `env:"WORKERS" transform:"trim"`
+		Name    string `env:"NAME" default:"Api" transform:"lower"`
+	}
+
+	cfg := &TransformConfig{}
+	err := NewEnvLoader().LoadFromMap(map[string]string{
+		"LEVEL":   "DEBUG",
+		"REGION":  "  eu-west-1 ",
+		"WORKERS": " 8\n",
+	}, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, "debug", cfg.Level) // transformed before validation
+	assert.Equal(t, "EU-WEST-1", cfg.Region)
+	assert.Equal(t, 8, cfg.Workers)  // trimmed before parsing
+	assert.Equal(t, "api", cfg.Name) // defaults are transformed too
+}
+
+func TestTransformTag_Unknown(t *testing.T) {
+	type TransformConfig struct {
+		Name string `env:"NAME" transform:"lower,reverse"`
+	}
+
+	err := NewEnvLoader().LoadFromMap(map[string]string{"NAME": "x"}, &TransformConfig{})
+	assert.EqualError(t, err, `env NAME (field Name): `+ErrUnknownTransform+` "reverse"`)
+}
@@ -0,0 +1,132 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// WithRejectUnknown reports an error when a source holds a variable that starts
+// with the loader's prefix but is not read by the config struct, catching typos
+// such as APP_DATABSE_URL. Only sources that can list their keys (the process
+// environment, MapSource and .env files) are checked. Without a prefix the
+// option has no effect.
+func WithRejectUnknown() Option {
+	return func(l *EnvLoader) {
+		l.rejectUnknown = true
+	}
+}
+
+// keyLister is implemented by sources that can enumerate their keys
+type keyLister interface {
+	keys() []string
+}
+
+// keys returns the names of all environment variables
+func (s *EnvSource) keys() []string {
+	return environKeys()
+}
+
+// keys returns the names of all environment variables
+func (s *foldedEnvSource) keys() []string {
+	return environKeys()
+}
+
+// keys returns the map's keys
+func (s MapSource) keys() []string {
+	keys := make([]string, 0, len(s))
+	for key := range s {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// environKeys returns the names of all variables in the process environment
+func environKeys() []string {
+	environ := os.Environ()
+	keys := make([]string, 0, len(environ))
+	for _, kv := range environ {
+		key, _, _ := strings.Cut(kv, "=")
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// checkUnknown returns an error listing the prefixed keys in the sources that
+// were not looked up during the load
+func (l *EnvLoader) checkUnknown(s *loadState) error {
+	if !l.rejectUnknown || l.prefix == "" {
+		return nil
+	}
+
+	normalize := func(key string) string { return key }
+	if l.foldCase {
+		normalize = strings.ToLower
+	}
+
+	consumed := make(map[string]bool, len(s.consumed))
+	for key := range s.consumed {
+		consumed[normalize(key)] = true
+	}
+
+	seen := make(map[string]bool)
+	var unknown []string
+	for _, source := range s.sources {
+		lister, ok := source.(keyLister)
+		if !ok {
+			continue
+		}
+		for _, key := range lister.keys() {
+			if !l.hasPrefix(key) || consumed[normalize(key)] || seen[key] {
+				continue
+			}
+			seen[key] = true
+			unknown = append(unknown, key)
+		}
+	}
+
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+	return fmt.Errorf("%s with prefix %s: %s", ErrUnknownVariables, l.prefix, strings.Join(unknown, ", "))
+}
+
+// markKnown records every name the field can be read from as consumed, so a
+// legacy name set alongside the canonical one is not reported as unknown
+func (l *EnvLoader) markKnown(s *loadState, envKey string, fieldType reflect.StructField) {
+	if s.consumed == nil {
+		return
+	}
+	for _, key := range l.fieldKeys(s, envKey, fieldType) {
+		s.consumed[key] = true
+	}
+}
+
+// fieldKeys returns envKey and the other names a field is looked up by: the
+// alternate names in its env tag, the same name under each fallback prefix, the
+// deprecated name and the WithAliases names
+func (l *EnvLoader) fieldKeys(s *loadState, envKey string, fieldType reflect.StructField) []string {
+	keys := []string{envKey}
+	for _, name := range alternateNames(fieldType) {
+		keys = append(keys, s.prefix+name)
+	}
+	name := strings.TrimPrefix(envKey, l.prefix)
+	for _, prefix := range l.fallbackPrefixes {
+		keys = append(keys, prefix+name)
+	}
+	if oldName := fieldType.Tag.Get(DeprecatedTag); oldName != "" {
+		keys = append(keys, s.prefix+oldName)
+	}
+	return append(keys, l.aliases[envKey]...)
+}
+
+// hasPrefix reports whether key starts with the loader's prefix
+func (l *EnvLoader) hasPrefix(key string) bool {
+	if l.foldCase {
+		return len(key) >= len(l.prefix) && strings.EqualFold(key[:len(l.prefix)], l.prefix)
+	}
+	return strings.HasPrefix(key, l.prefix)
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type unknownConfig struct {
+	Host string `env:"HOST"`
+	Port int    `env:"PORT" default:"8080"`
+	DB   struct {
+		URL string `env:"URL"`
+	} `envPrefix:"DB_"`
+}
+
+func TestWithRejectUnknown(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  map[string]string
+		wantErr string
+	}{
+		{
+			name:   "only known variables",
+			values: map[string]string{"APP_HOST": "localhost", "APP_DB_URL": "postgres://db", "OTHER_VAR": "x"},
+		},
+		{
+			name:    "unknown prefixed variable",
+			values:  map[string]string{"APP_HOST": "localhost", "APP_TYPO": "1"},
+			wantErr: "unknown variables with prefix APP_: APP_TYPO",
+		},
+		{
+			name:    "unknown variables are sorted",
+			values:  map[string]string{"APP_DATABSE_URL": "x", "APP_DB_URI": "y"},
+			wantErr: "APP_DATABSE_URL, APP_DB_URI",
+		},
+	}
+
+	loader := NewEnvLoader(WithPrefix("APP_"), WithRejectUnknown())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := loader.LoadFromMap(tt.values, &unknownConfig{})
+			if tt.wantErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestWithRejectUnknown_Env(t *testing.T) {
+	os.Setenv("UNKNOWN_HOST", "localhost")
+	os.Setenv("UNKNOWN_TYPO", "1")
+	defer os.Unsetenv("UNKNOWN_HOST")
+	defer os.Unsetenv("UNKNOWN_TYPO")
+
+	err := NewEnvLoader(WithPrefix("UNKNOWN_"), WithRejectUnknown()).LoadConfig(&unknownConfig{})
+	assert.ErrorContains(t, err, "UNKNOWN_TYPO")
+
+	// Without the option unknown variables are ignored
+	err = NewEnvLoader(WithPrefix("UNKNOWN_")).LoadConfig(&unknownConfig{})
+	assert.NoError(t, err)
+}
+
+func TestWithRejectUnknown_DeclaredNames(t *testing.T) {
+	type legacyConfig struct {
+		Port int    `env:"PORT" deprecated:"OLD_PORT"`
+		Host string `env:"HOST"`
+		URL  string `env:"DATABASE_URL,DB_URL"`
+	}
+
+	tests := []struct {
+		name   string
+		opts   []Option
+		values map[string]string
+	}{
+		{
+			name:   "deprecated name set alongside the canonical one",
+			values: map[string]string{"APP_PORT": "8080", "APP_OLD_PORT": "9090"},
+		},
+		{
+			name:   "fallback prefix set alongside the primary one",
+			opts:   []Option{WithPrefixes("APP_", "APP_LEGACY_")},
+			values: map[string]string{"APP_HOST": "a.local", "APP_LEGACY_HOST": "b.local"},
+		},
+		{
+			name:   "alternate name set alongside the first one",
+			values: map[string]string{"APP_DATABASE_URL": "postgres://a", "APP_DB_URL": "postgres://b"},
+		},
+		{
+			name:   "alias set alongside the canonical name",
+			opts:   []Option{WithAliases(map[string]string{"APP_SERVICE_HOST": "APP_HOST"})},
+			values: map[string]string{"APP_HOST": "a.local", "APP_SERVICE_HOST": "b.local"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]Option{WithPrefix("APP_"), WithRejectUnknown()}, tt.opts...)
+			err := NewEnvLoader(opts...).LoadFromMap(tt.values, &legacyConfig{})
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+)
+
+// Usage returns a listing of every environment variable cfg expects using the default loader
+func Usage(cfg interface{}) string {
+	return defaultLoader.Usage(cfg)
+}
+
+// Usage returns a listing of every environment variable cfg expects, one aligned
+// line per variable with its type and whether it is required or has a default.
+// A description can be supplied with the desc tag.
+func (l *EnvLoader) Usage(cfg interface{}) string {
+	v, ok := configStruct(cfg)
+	if !ok {
+		return ""
+	}
+
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+
+	_ = l.walkFields(v, l.prefix, "", func(f fieldRef) error {
+		annotation := "(optional)"
+		if def := l.defaultValue(f.EnvKey, f.Field, nil); def != "" {
+			annotation = fmt.Sprintf("(default: %s)", def)
+		}
+		if l.isRequiredTag(f.Field.Tag) {
+			annotation = "(required)"
+		}
+
+		line := fmt.Sprintf("%s\t%s\t%s", f.EnvKey, f.Field.Type, annotation)
+		if desc := f.Field.Tag.Get(DescTag); desc != "" {
+			line += " - " + desc
+		}
+		fmt.Fprintln(w, line)
+		return nil
+	})
+
+	w.Flush()
+	return b.String()
+}
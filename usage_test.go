@@ -0,0 +1,75 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsage(t *testing.T) {
+	type DatabaseConfig struct {
+		URL string `env:"URL" required:"true" desc:"database connection string"`
+	}
+
+	type UsageConfig struct {
+		Port     int            `env:"PORT" default:"8080"`
+		Debug    bool           `env:"DEBUG"`
+		Database DatabaseConfig `envPrefix:"DATABASE_"`
+		Ignored  string
+	}
+
+	usage := Usage(&UsageConfig{})
+	lines := strings.Split(strings.TrimSpace(usage), "\n")
+	assert.Len(t, lines, 3)
+
+	assert.Regexp(t, `^PORT\s+int\s+\(default: 8080\)$`, lines[0])
+	assert.Regexp(t, `^DEBUG\s+bool\s+\(optional\)$`, lines[1])
+	assert.Regexp(t, `^DATABASE_URL\s+string\s+\(required\) - database connection string$`, lines[2])
+
+	// Columns are aligned
+	assert.Equal(t, strings.Index(lines[0], "int"), strings.Index(lines[2], "string"))
+}
+
+func TestUsageWithPrefix(t *testing.T) {
+	type UsageConfig struct {
+		Port int `env:"PORT" required:"true"`
+	}
+
+	usage := NewEnvLoader(WithPrefix("APP_")).Usage((*UsageConfig)(nil))
+	assert.Contains(t, usage, "APP_PORT")
+	assert.Contains(t, usage, "(required)")
+
+	assert.Empty(t, Usage(42))
+}
+
+func TestUsageWithDefaults(t *testing.T) {
+	type UsageConfig struct {
+		Port int `env:"PORT" default:"8080"`
+	}
+
+	usage := NewEnvLoader(WithDefaults(map[string]string{"PORT": "9090"})).Usage(&UsageConfig{})
+	assert.Contains(t, usage, "(default: 9090)")
+}
+
+func TestUsageWithRequiredByDefault(t *testing.T) {
+	type UsageConfig struct {
+		Port  int    `env:"PORT" default:"8080"`
+		Host  string `env:"HOST"`
+		Debug bool   `env:"DEBUG" required:"false"`
+	}
+
+	loader := NewEnvLoader(WithRequiredByDefault())
+	usage := loader.Usage(&UsageConfig{})
+	assert.Regexp(t, `PORT\s+int\s+\(default: 8080\)`, usage)
+	assert.Regexp(t, `HOST\s+string\s+\(required\)`, usage)
+	assert.Regexp(t, `DEBUG\s+bool\s+\(optional\)`, usage)
+
+	fields := loader.Describe(&UsageConfig{})
+	if assert.Len(t, fields, 3) {
+		assert.False(t, fields[0].Required)
+		assert.True(t, fields[1].Required)
+		assert.False(t, fields[2].Required)
+	}
+	assert.Contains(t, loader.GenerateEnvTemplate(&UsageConfig{}), "# required\nHOST=\n")
+}
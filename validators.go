@@ -2,28 +2,119 @@ package config
 
 import (
 	"fmt"
+	"net/mail"
+	"net/url"
 	"reflect"
+	"regexp"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 )
 
 // RequiredValidator ensures a field isn't empty or zero
-type RequiredValidator struct{}
+type RequiredValidator struct {
+	// ByDefault requires fields that have no required tag, unless they have a default
+	ByDefault bool
+}
 
 // Validate checks if the field satisfies the required constraint
 func (v *RequiredValidator) Validate(field reflect.Value, tags reflect.StructTag) error {
-	if tags.Get(RequiredTag) != TagTrue {
+	if !v.isRequired(tags) {
 		return nil
 	}
 
 	if isZeroValue(field) {
-		return fmt.Errorf(ErrRequiredField)
+		errMsg := tags.Get(RequiredErrTag)
+		if errMsg == "" {
+			errMsg = ErrRequiredField
+		}
+		return &requiredError{msg: errMsg}
+	}
+
+	return nil
+}
+
+// isRequired reports whether the tags make a field required
+func (v *RequiredValidator) isRequired(tags reflect.StructTag) bool {
+	switch tags.Get(RequiredTag) {
+	case TagTrue:
+		return true
+	case "":
+		_, hasDefault := tags.Lookup(DefaultTag)
+		return v.ByDefault && !hasDefault
+	}
+	return false
+}
+
+// NotBlankValidator rejects empty and whitespace-only strings tagged notblank:"true"
+type NotBlankValidator struct{}
+
+// Validate checks that a string field contains a non-whitespace character
+func (v *NotBlankValidator) Validate(field reflect.Value, tags reflect.StructTag) error {
+	if tags.Get(NotBlankTag) != TagTrue {
+		return nil
 	}
 
+	field, ok := indirect(field)
+	if !ok || field.Kind() != reflect.String {
+		return nil
+	}
+
+	if strings.TrimSpace(field.String()) == "" {
+		return &requiredError{msg: ErrBlankValue}
+	}
+	return nil
+}
+
+// requiredError reports a missing required value
+type requiredError struct {
+	msg string
+}
+
+func (e *requiredError) Error() string {
+	return e.msg
+}
+
+// RequiredIfValidator requires a field when a sibling field has a given value,
+// e.g. required_if:"TLSEnabled=true"
+type RequiredIfValidator struct{}
+
+// ValidateWithParent checks the condition against the sibling field in parent
+func (v *RequiredIfValidator) ValidateWithParent(parent reflect.Value, field reflect.Value, tags reflect.StructTag) error {
+	condition := tags.Get(RequiredIfTag)
+	if condition == "" {
+		return nil
+	}
+
+	name, want, ok := strings.Cut(condition, "=")
+	if !ok {
+		return fmt.Errorf("invalid required_if condition %q: expected Field=value", condition)
+	}
+
+	siblingType, ok := parent.Type().FieldByName(name)
+	if !ok {
+		return fmt.Errorf("invalid required_if condition %q: unknown field %s", condition, name)
+	}
+	if !siblingType.IsExported() {
+		return fmt.Errorf("invalid required_if condition %q: unexported field %s", condition, name)
+	}
+	sibling, err := parent.FieldByIndexErr(siblingType.Index)
+	if err != nil {
+		// Promoted through a nil embedded pointer, so the sibling is unset
+		return nil
+	}
+
+	if formatValue(sibling) == want && isZeroValue(field) {
+		return &requiredError{msg: fmt.Sprintf("%s: required when %s", ErrRequiredField, condition)}
+	}
 	return nil
 }
 
 func isZeroValue(v reflect.Value) bool {
 	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
 	case reflect.Slice, reflect.Map:
 		return v.Len() == 0
 	case reflect.String:
@@ -37,29 +128,71 @@ func isZeroValue(v reflect.Value) bool {
 	}
 }
 
+// indirect dereferences pointer fields, reporting false for nil pointers
+func indirect(field reflect.Value) (reflect.Value, bool) {
+	for field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return field, false
+		}
+		field = field.Elem()
+	}
+	return field, true
+}
+
 // RangeValidator checks if a field's value falls within a specified range
 type RangeValidator struct{}
 
-// Validate checks if the field satisfies the range constraints
+// Validate checks if the field satisfies the range constraints.
+// min/max (or their aliases gte/lte) are inclusive, gt/lt are exclusive.
 func (v *RangeValidator) Validate(field reflect.Value, tags reflect.StructTag) error {
-	min := tags.Get(MinTag)
-	max := tags.Get(MaxTag)
-	if min == "" && max == "" {
+	field, ok := indirect(field)
+	if !ok {
+		return nil
+	}
+
+	min := tagOr(tags, MinTag, GteTag)
+	max := tagOr(tags, MaxTag, LteTag)
+	gt := tags.Get(GtTag)
+	lt := tags.Get(LtTag)
+	if min == "" && max == "" && gt == "" && lt == "" {
 		return nil
 	}
 
 	var err error
-	value := field.Interface()
 	errMsg := tags.Get(RangeErrTag)
 	if errMsg == "" {
 		errMsg = ErrOutOfRange
 	}
 
-	switch v := value.(type) {
-	case int, int64:
-		err = validateIntRange(v, min, max)
-	case float64:
-		err = validateFloatRange(v, min, max)
+	if d, ok := field.Interface().(time.Duration); ok {
+		err = validateDurationRange(d, tags, min, max, gt, lt)
+	} else {
+		switch field.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			err = validateIntRange(field.Int(), min, max)
+			if err == nil {
+				err = validateIntExclusive(field.Int(), gt, lt)
+			}
+		case reflect.Float32, reflect.Float64:
+			err = validateFloatRange(field.Float(), min, max)
+			if err == nil {
+				err = validateFloatExclusive(field.Float(), gt, lt)
+			}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			err = validateUintRange(field.Uint(), min, max)
+			if err == nil {
+				err = validateUintExclusive(field.Uint(), gt, lt)
+			}
+		case reflect.String:
+			// Strings are only checked when the bounds are explicitly meant as lengths
+			if tags.Get(RangeAsLengthTag) == TagTrue {
+				n := len(field.String())
+				err = validateIntRange(n, min, max)
+				if err == nil {
+					err = validateIntExclusive(int64(n), gt, lt)
+				}
+			}
+		}
 	}
 
 	if err != nil {
@@ -103,6 +236,68 @@ func validateIntRange(value interface{}, minStr, maxStr string) error {
 	return nil
 }
 
+// validateUintRange checks if an unsigned integer value falls within the specified range
+func validateUintRange(value uint64, minStr, maxStr string) error {
+	if minStr != "" {
+		min, err := parseUintBound("min", minStr)
+		if err != nil {
+			return err
+		}
+		if value < min {
+			return fmt.Errorf("value %d is less than minimum %d", value, min)
+		}
+	}
+
+	if maxStr != "" {
+		max, err := parseUintBound("max", maxStr)
+		if err != nil {
+			return err
+		}
+		if value > max {
+			return fmt.Errorf("value %d is greater than maximum %d", value, max)
+		}
+	}
+
+	return nil
+}
+
+// validateUintExclusive checks if an unsigned integer value lies strictly between the gt/lt bounds
+func validateUintExclusive(value uint64, gtStr, ltStr string) error {
+	if gtStr != "" {
+		gt, err := parseUintBound("gt", gtStr)
+		if err != nil {
+			return err
+		}
+		if value <= gt {
+			return fmt.Errorf("value %d is not greater than %d", value, gt)
+		}
+	}
+
+	if ltStr != "" {
+		lt, err := parseUintBound("lt", ltStr)
+		if err != nil {
+			return err
+		}
+		if value >= lt {
+			return fmt.Errorf("value %d is not less than %d", value, lt)
+		}
+	}
+
+	return nil
+}
+
+// parseUintBound parses a bound tag for an unsigned field, rejecting negative values
+func parseUintBound(name, bound string) (uint64, error) {
+	if strings.HasPrefix(bound, "-") {
+		return 0, fmt.Errorf("invalid %s value %q: unsigned fields cannot have negative bounds", name, bound)
+	}
+	v, err := strconv.ParseUint(bound, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s value: %w", name, err)
+	}
+	return v, nil
+}
+
 // validateFloatRange checks if a float value falls within the specified range
 func validateFloatRange(value float64, minStr, maxStr string) error {
 	if minStr != "" {
@@ -127,3 +322,444 @@ func validateFloatRange(value float64, minStr, maxStr string) error {
 
 	return nil
 }
+
+// validateIntExclusive checks if an integer value lies strictly between the gt/lt bounds
+func validateIntExclusive(value int64, gtStr, ltStr string) error {
+	if gtStr != "" {
+		gt, err := strconv.ParseInt(gtStr, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid gt value: %w", err)
+		}
+		if value <= gt {
+			return fmt.Errorf("value %d is not greater than %d", value, gt)
+		}
+	}
+
+	if ltStr != "" {
+		lt, err := strconv.ParseInt(ltStr, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid lt value: %w", err)
+		}
+		if value >= lt {
+			return fmt.Errorf("value %d is not less than %d", value, lt)
+		}
+	}
+
+	return nil
+}
+
+// validateFloatExclusive checks if a float value lies strictly between the gt/lt bounds
+func validateFloatExclusive(value float64, gtStr, ltStr string) error {
+	if gtStr != "" {
+		gt, err := strconv.ParseFloat(gtStr, 64)
+		if err != nil {
+			return fmt.Errorf("invalid gt value: %w", err)
+		}
+		if value <= gt {
+			return fmt.Errorf("value %f is not greater than %f", value, gt)
+		}
+	}
+
+	if ltStr != "" {
+		lt, err := strconv.ParseFloat(ltStr, 64)
+		if err != nil {
+			return fmt.Errorf("invalid lt value: %w", err)
+		}
+		if value >= lt {
+			return fmt.Errorf("value %f is not less than %f", value, lt)
+		}
+	}
+
+	return nil
+}
+
+// validateDurationRange checks a duration against inclusive min/max and
+// exclusive gt/lt bounds, which are read with the field's duration tags
+func validateDurationRange(value time.Duration, tags reflect.StructTag, minStr, maxStr, gtStr, ltStr string) error {
+	bounds := []struct {
+		name, value string
+		fails       func(d time.Duration) bool
+		msg         string
+	}{
+		{"min", minStr, func(d time.Duration) bool { return value < d }, "value %s is less than minimum %s"},
+		{"max", maxStr, func(d time.Duration) bool { return value > d }, "value %s is greater than maximum %s"},
+		{"gt", gtStr, func(d time.Duration) bool { return value <= d }, "value %s is not greater than %s"},
+		{"lt", ltStr, func(d time.Duration) bool { return value >= d }, "value %s is not less than %s"},
+	}
+
+	for _, b := range bounds {
+		if b.value == "" {
+			continue
+		}
+		var d time.Duration
+		if err := (&DurationParser{}).ParseWithTags(b.value, reflect.ValueOf(&d).Elem(), tags); err != nil {
+			return fmt.Errorf("invalid %s value: %w", b.name, err)
+		}
+		if b.fails(d) {
+			return fmt.Errorf(b.msg, value, d)
+		}
+	}
+	return nil
+}
+
+// tagOr returns the value of the first non-empty tag among keys
+func tagOr(tags reflect.StructTag, keys ...string) string {
+	for _, key := range keys {
+		if value := tags.Get(key); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// StepValidator checks that integer fields are a multiple of the multiple_of tag
+type StepValidator struct{}
+
+// Validate checks if the field's value is divisible by the configured step
+func (v *StepValidator) Validate(field reflect.Value, tags reflect.StructTag) error {
+	stepStr := tags.Get(MultipleOfTag)
+	if stepStr == "" {
+		return nil
+	}
+
+	field, ok := indirect(field)
+	if !ok {
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		step, err := strconv.ParseInt(stepStr, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid multiple_of value: %w", err)
+		}
+		if step == 0 {
+			return fmt.Errorf("invalid multiple_of value: step must not be zero")
+		}
+		if field.Int()%step != 0 {
+			return fmt.Errorf("%s: %d is not a multiple of %d", ErrNotMultiple, field.Int(), step)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		step, err := strconv.ParseUint(stepStr, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid multiple_of value: %w", err)
+		}
+		if step == 0 {
+			return fmt.Errorf("invalid multiple_of value: step must not be zero")
+		}
+		if field.Uint()%step != 0 {
+			return fmt.Errorf("%s: %d is not a multiple of %d", ErrNotMultiple, field.Uint(), step)
+		}
+	}
+
+	return nil
+}
+
+// PatternValidator checks that string fields match the regular expression in the pattern tag
+type PatternValidator struct {
+	cache sync.Map // pattern string -> *regexp.Regexp
+}
+
+// Validate checks if a non-empty string field matches its pattern
+func (v *PatternValidator) Validate(field reflect.Value, tags reflect.StructTag) error {
+	pattern := tags.Get(PatternTag)
+	if pattern == "" {
+		return nil
+	}
+
+	field, ok := indirect(field)
+	if !ok || field.Kind() != reflect.String || field.String() == "" {
+		return nil
+	}
+
+	re, err := v.compile(pattern)
+	if err != nil {
+		return fmt.Errorf("%s %q: %w", ErrInvalidPattern, pattern, err)
+	}
+
+	if !re.MatchString(field.String()) {
+		errMsg := tags.Get(PatternErrTag)
+		if errMsg == "" {
+			errMsg = ErrPatternMismatch
+		}
+		return fmt.Errorf("%s: %q", errMsg, pattern)
+	}
+	return nil
+}
+
+// compile returns the compiled regular expression for pattern, caching the result
+func (v *PatternValidator) compile(pattern string) (*regexp.Regexp, error) {
+	if re, ok := v.cache.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	v.cache.Store(pattern, re)
+	return re, nil
+}
+
+// OneOfValidator checks that a field's value is one of the space-separated values in the oneof tag
+type OneOfValidator struct{}
+
+// Validate checks if the field's string representation is in the allowed set.
+// Matching is case-sensitive and zero values are skipped. With oneof_ci:"true",
+// string fields match case-insensitively and are rewritten to the casing used
+// in the tag.
+func (v *OneOfValidator) Validate(field reflect.Value, tags reflect.StructTag) error {
+	allowed := strings.Fields(tags.Get(OneOfTag))
+	if len(allowed) == 0 {
+		return nil
+	}
+
+	field, ok := indirect(field)
+	if !ok || isZeroValue(field) {
+		return nil
+	}
+
+	var value string
+	switch field.Kind() {
+	case reflect.String:
+		value = field.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value = strconv.FormatInt(field.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value = strconv.FormatUint(field.Uint(), 10)
+	default:
+		return nil
+	}
+
+	foldCase := field.Kind() == reflect.String && tags.Get(OneOfCITag) == TagTrue
+	for _, a := range allowed {
+		if value == a {
+			return nil
+		}
+		if foldCase && strings.EqualFold(value, a) {
+			if field.CanSet() {
+				field.SetString(a)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("%s: %q not in [%s]", ErrNotOneOf, value, strings.Join(allowed, ", "))
+}
+
+// LengthValidator checks the length of string, slice, and map fields against minlen/maxlen tags.
+// Slices tagged no_empty:"true" are also rejected when they contain empty elements.
+type LengthValidator struct{}
+
+// Validate checks if the field's length satisfies the minlen/maxlen constraints
+func (v *LengthValidator) Validate(field reflect.Value, tags reflect.StructTag) error {
+	minStr := tags.Get(MinLenTag)
+	maxStr := tags.Get(MaxLenTag)
+	noEmpty := tags.Get(NoEmptyTag) == TagTrue
+	if minStr == "" && maxStr == "" && !noEmpty {
+		return nil
+	}
+
+	field, ok := indirect(field)
+	if !ok {
+		return nil
+	}
+
+	if noEmpty {
+		if err := checkNoEmptyElements(field); err != nil {
+			return err
+		}
+	}
+
+	var length int
+	switch field.Kind() {
+	case reflect.String:
+		length = len(field.String())
+	case reflect.Slice, reflect.Array, reflect.Map:
+		length = field.Len()
+	default:
+		return nil
+	}
+
+	if minStr != "" {
+		min, err := strconv.Atoi(minStr)
+		if err != nil {
+			return fmt.Errorf("invalid minlen value: %w", err)
+		}
+		if length < min {
+			return fmt.Errorf("%s: length %d is less than minimum %d", ErrLengthOutOfRange, length, min)
+		}
+	}
+
+	if maxStr != "" {
+		max, err := strconv.Atoi(maxStr)
+		if err != nil {
+			return fmt.Errorf("invalid maxlen value: %w", err)
+		}
+		if length > max {
+			return fmt.Errorf("%s: length %d is greater than maximum %d", ErrLengthOutOfRange, length, max)
+		}
+	}
+
+	return nil
+}
+
+// checkNoEmptyElements reports the first zero-valued element of a slice or array,
+// such as the blank produced by a stray comma in "a,,b"
+func checkNoEmptyElements(field reflect.Value) error {
+	if field.Kind() != reflect.Slice && field.Kind() != reflect.Array {
+		return nil
+	}
+	for i := 0; i < field.Len(); i++ {
+		if isZeroValue(field.Index(i)) {
+			return fmt.Errorf("%s at index %d", ErrEmptyElement, i)
+		}
+	}
+	return nil
+}
+
+// URLValidator checks url.URL fields tagged require_scheme:"true" have a scheme
+type URLValidator struct{}
+
+// Validate checks if a non-empty URL field has a scheme when one is required
+func (v *URLValidator) Validate(field reflect.Value, tags reflect.StructTag) error {
+	if tags.Get(RequireSchemeTag) != TagTrue {
+		return nil
+	}
+
+	field, ok := indirect(field)
+	if !ok || isZeroValue(field) {
+		return nil
+	}
+
+	u, ok := field.Interface().(url.URL)
+	if ok && u.Scheme == "" {
+		return fmt.Errorf("%s: %q", ErrMissingScheme, u.String())
+	}
+	return nil
+}
+
+// TimeRangeValidator checks time.Time fields against the after and before tags,
+// which are parsed with the field's layout and timezone tags. Both bounds are
+// exclusive and unset times are not checked.
+type TimeRangeValidator struct{}
+
+// Validate checks if a non-zero time falls strictly between its bounds
+func (v *TimeRangeValidator) Validate(field reflect.Value, tags reflect.StructTag) error {
+	after, before := tags.Get(AfterTag), tags.Get(BeforeTag)
+	if after == "" && before == "" {
+		return nil
+	}
+
+	field, ok := indirect(field)
+	if !ok {
+		return nil
+	}
+	t, ok := field.Interface().(time.Time)
+	if !ok || t.IsZero() {
+		return nil
+	}
+
+	if after != "" {
+		bound, err := parseTimeBound(AfterTag, after, tags)
+		if err != nil {
+			return err
+		}
+		if !t.After(bound) {
+			return fmt.Errorf("%s %s, got %s", ErrTimeNotAfter, after, formatTime(t, tags))
+		}
+	}
+	if before != "" {
+		bound, err := parseTimeBound(BeforeTag, before, tags)
+		if err != nil {
+			return err
+		}
+		if !t.Before(bound) {
+			return fmt.Errorf("%s %s, got %s", ErrTimeNotBefore, before, formatTime(t, tags))
+		}
+	}
+	return nil
+}
+
+// parseTimeBound parses an after or before tag value with the field's time tags.
+// Without a layout tag a date-only bound such as 2020-01-01 is accepted too.
+func parseTimeBound(name, value string, tags reflect.StructTag) (time.Time, error) {
+	var bound time.Time
+	err := (&TimeParser{}).ParseWithTags(value, reflect.ValueOf(&bound).Elem(), tags)
+	if err != nil && tags.Get(LayoutTag) == "" {
+		dateTags := reflect.StructTag(fmt.Sprintf(`%s %s:%q`, tags, LayoutTag, time.DateOnly))
+		if (&TimeParser{}).ParseWithTags(value, reflect.ValueOf(&bound).Elem(), dateTags) == nil {
+			err = nil
+		}
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s value %q: %w", name, value, err)
+	}
+	return bound, nil
+}
+
+// formatTime formats t with the field's layout tag, RFC 3339 by default
+func formatTime(t time.Time, tags reflect.StructTag) string {
+	layout := tags.Get(LayoutTag)
+	if layout == "" {
+		layout = time.RFC3339
+	}
+	return t.Format(layout)
+}
+
+// hostnameRegexp matches RFC 1123 host names: dot-separated labels of letters,
+// digits and inner hyphens, each at most 63 characters long
+var hostnameRegexp = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`)
+
+// FormatValidator checks that a string field matches the well-known format named
+// by the format tag: email, hostname or uri. The json, clock and kv formats
+// select a parser rather than a check and are ignored here.
+type FormatValidator struct{}
+
+// Validate checks if a non-empty string field matches its format
+func (v *FormatValidator) Validate(field reflect.Value, tags reflect.StructTag) error {
+	format := tags.Get(FormatTag)
+	if format == "" || format == FormatJSON || format == FormatClock || format == FormatKV {
+		return nil
+	}
+
+	check, ok := formatChecks[format]
+	if !ok {
+		return fmt.Errorf("%s %q", ErrUnknownFormat, format)
+	}
+
+	field, ok = indirect(field)
+	if !ok || field.Kind() != reflect.String || field.String() == "" {
+		return nil
+	}
+
+	if !check(field.String()) {
+		return fmt.Errorf("%s %s: %q", ErrFormatMismatch, format, field.String())
+	}
+	return nil
+}
+
+// formatChecks maps format tag values to their checks
+var formatChecks = map[string]func(string) bool{
+	FormatEmail: func(s string) bool {
+		addr, err := mail.ParseAddress(s)
+		return err == nil && addr.Address == s // reject display-name forms
+	},
+	FormatHostname: func(s string) bool {
+		return len(s) <= 253 && hostnameRegexp.MatchString(s)
+	},
+	FormatURI: func(s string) bool {
+		u, err := url.Parse(s)
+		if err != nil || u.Scheme == "" {
+			return false
+		}
+		// "host:port" parses with the host as scheme and the port as opaque
+		// data, so require an authority or a non-numeric opaque part
+		return u.Host != "" || (u.Opaque != "" && !isPort(u.Opaque))
+	},
+}
+
+// isPort reports whether s is a bare port number
+func isPort(s string) bool {
+	_, err := strconv.ParseUint(s, 10, 16)
+	return err == nil
+}
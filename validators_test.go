@@ -1,9 +1,12 @@
 package config
 
 import (
+	"net/url"
 	"reflect"
+	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -43,6 +46,59 @@ func TestRequiredValidator_Validate(t *testing.T) {
 	}
 }
 
+func TestRequiredValidator_ByDefault(t *testing.T) {
+	validator := &RequiredValidator{ByDefault: true}
+
+	assert.Error(t, validator.Validate(reflect.ValueOf(""), ``))
+	assert.Error(t, validator.Validate(reflect.ValueOf((*int)(nil)), ``))
+	assert.NoError(t, validator.Validate(reflect.ValueOf("set"), ``))
+	assert.NoError(t, validator.Validate(reflect.ValueOf(""), `required:"false"`))
+	assert.NoError(t, validator.Validate(reflect.ValueOf(""), `default:""`))
+}
+
+func TestRequiredValidator_CustomError(t *testing.T) {
+	validator := &RequiredValidator{}
+
+	err := validator.Validate(reflect.ValueOf(""), `required:"true" required_error:"DATABASE_URL must be set"`)
+	assert.EqualError(t, err, "DATABASE_URL must be set")
+
+	err = validator.Validate(reflect.ValueOf(""), `required:"true"`)
+	assert.EqualError(t, err, ErrRequiredField)
+
+	// The custom message alone does not make a field required
+	err = validator.Validate(reflect.ValueOf(""), `required_error:"DATABASE_URL must be set"`)
+	assert.NoError(t, err)
+}
+
+func TestNotBlankValidator_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		tag     string
+		wantErr bool
+	}{
+		{"whitespace only", "   ", `notblank:"true"`, true},
+		{"empty", "", `notblank:"true"`, true},
+		{"non-blank", "x", `notblank:"true"`, false},
+		{"padded non-blank", "  x ", `notblank:"true"`, false},
+		{"whitespace without flag", "   ", `required:"true"`, false},
+		{"empty without flag", "", ``, false},
+		{"non-string ignored", 0, `notblank:"true"`, false},
+	}
+
+	validator := &NotBlankValidator{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Validate(reflect.ValueOf(tt.value), reflect.StructTag(tt.tag))
+			if tt.wantErr {
+				assert.EqualError(t, err, ErrBlankValue)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func Test_isZeroValue(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -57,6 +113,8 @@ func Test_isZeroValue(t *testing.T) {
 		{"non-empty slice", []string{"test"}, false},
 		{"zero float", 0.0, true},
 		{"non-zero float", 3.14, false},
+		{"nil pointer", (*int)(nil), true},
+		{"pointer to zero", new(int), false},
 	}
 
 	for _, tt := range tests {
@@ -68,6 +126,8 @@ func Test_isZeroValue(t *testing.T) {
 }
 
 func TestRangeValidator_Validate(t *testing.T) {
+	type level int32
+
 	tests := []struct {
 		name    string
 		value   interface{}
@@ -79,7 +139,34 @@ func TestRangeValidator_Validate(t *testing.T) {
 		{"int above max", 11, `min:"0" max:"10"`, true},
 		{"float in range", 5.5, `min:"0.0" max:"10.0"`, false},
 		{"float out of range", 10.1, `min:"0.0" max:"10.0"`, true},
+		{"float32 in range", float32(5.5), `min:"0.0" max:"10.0"`, false},
+		{"float32 below min", float32(-0.5), `min:"0.0" max:"10.0"`, true},
+		{"float32 above max", float32(10.5), `min:"0.0" max:"10.0"`, true},
 		{"custom error message", 11, `min:"0" max:"10" range_error:"custom error"`, true},
+		{"int equal to gt", 0, `gt:"0"`, true},
+		{"int just above gt", 1, `gt:"0"`, false},
+		{"int equal to lt", 10, `lt:"10"`, true},
+		{"int just below lt", 9, `lt:"10"`, false},
+		{"gt with max in range", 10, `gt:"0" max:"10"`, false},
+		{"gt with max above max", 11, `gt:"0" max:"10"`, true},
+		{"float equal to gt", 0.0, `gt:"0"`, true},
+		{"float just above gt", 0.001, `gt:"0"`, false},
+		{"float32 equal to lt", float32(1.5), `lt:"1.5"`, true},
+		{"gte alias inclusive", 0, `gte:"0"`, false},
+		{"lte alias inclusive", 10, `lte:"10"`, false},
+		{"lte alias above", 11, `lte:"10"`, true},
+		{"invalid gt", 5, `gt:"abc"`, true},
+		{"string length in range", "abcd", `min:"2" max:"8" range_as_length:"true"`, false},
+		{"string length below min", "a", `min:"2" max:"8" range_as_length:"true"`, true},
+		{"string length above max", "abcdefghi", `min:"2" max:"8" range_as_length:"true"`, true},
+		{"string ignored without flag", "a", `min:"2" max:"8"`, false},
+		{"int8 in range", int8(15), `min:"10" max:"20"`, false},
+		{"int8 above max", int8(99), `min:"10" max:"20"`, true},
+		{"int16 equal to gt", int16(0), `gt:"0"`, true},
+		{"int16 below min", int16(-5), `min:"0"`, true},
+		{"int32 above max", int32(99), `min:"10" max:"20"`, true},
+		{"int32 equal to lt", int32(20), `lt:"20"`, true},
+		{"named int above max", level(99), `max:"20"`, true},
 	}
 
 	validator := &RangeValidator{}
@@ -130,6 +217,68 @@ func TestValidateIntRange_EdgeCases(t *testing.T) {
 	}
 }
 
+func TestValidateUintRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		tag     string
+		wantErr string
+	}{
+		{"in range", uint(50), `min:"10" max:"100"`, ""},
+		{"below min", uint32(5), `min:"10" max:"100"`, "value 5 is less than minimum 10"},
+		{"above max", uint64(101), `min:"10" max:"100"`, "value 101 is greater than maximum 100"},
+		{"uint8 in range", uint8(255), `max:"255"`, ""},
+		{"uint16 equal to gt", uint16(0), `gt:"0"`, "value 0 is not greater than 0"},
+		{"uint16 above gt", uint16(1), `gt:"0"`, ""},
+		{"large uint64", uint64(1 << 63), `min:"9223372036854775807"`, ""},
+		{"negative min", uint(5), `min:"-1"`, `invalid min value "-1": unsigned fields cannot have negative bounds`},
+		{"invalid max", uint(5), `max:"abc"`, "invalid max value"},
+	}
+
+	validator := &RangeValidator{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Validate(reflect.ValueOf(tt.value), reflect.StructTag(tt.tag))
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateDurationRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   time.Duration
+		tag     string
+		wantErr string
+	}{
+		{"in range", 5 * time.Second, `min:"1s" max:"30s"`, ""},
+		{"below min", 500 * time.Millisecond, `min:"1s" max:"30s"`, "value 500ms is less than minimum 1s"},
+		{"above max", 45 * time.Second, `min:"1s" max:"30s"`, "value 45s is greater than maximum 30s"},
+		{"equal to bounds", 30 * time.Second, `min:"30s" max:"30s"`, ""},
+		{"bare bound uses unit tag", 2 * time.Minute, `max:"1" unit:"m"`, "value 2m0s is greater than maximum 1m0s"},
+		{"equal to gt", time.Second, `gt:"1s"`, "value 1s is not greater than 1s"},
+		{"below lt", time.Second, `lt:"1m"`, ""},
+		{"custom error message", time.Hour, `max:"30s" range_error:"timeout too long"`, "timeout too long"},
+		{"invalid max", time.Second, `max:"soon"`, "invalid max value"},
+	}
+
+	validator := &RangeValidator{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Validate(reflect.ValueOf(tt.value), reflect.StructTag(tt.tag))
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestValidateFloatRange_EdgeCases(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -158,3 +307,370 @@ func TestValidateFloatRange_EdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestStepValidator_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		tag     string
+		wantErr string
+	}{
+		{"in step", 4096, `multiple_of:"1024"`, ""},
+		{"off step", 1000, `multiple_of:"1024"`, ErrNotMultiple + ": 1000 is not a multiple of 1024"},
+		{"zero value", 0, `multiple_of:"1024"`, ""},
+		{"uint in step", uint32(2048), `multiple_of:"1024"`, ""},
+		{"uint off step", uint32(100), `multiple_of:"1024"`, ErrNotMultiple},
+		{"zero step", 4096, `multiple_of:"0"`, "step must not be zero"},
+		{"invalid step", 4096, `multiple_of:"abc"`, "invalid multiple_of value"},
+		{"no tag", 1000, ``, ""},
+		{"non-integer ignored", "1000", `multiple_of:"1024"`, ""},
+	}
+
+	validator := &StepValidator{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Validate(reflect.ValueOf(tt.value), reflect.StructTag(tt.tag))
+			if tt.wantErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run("coexists with min and max", func(t *testing.T) {
+		type StepConfig struct {
+			Buffer int `env:"STEP_BUFFER" min:"1024" max:"65536" multiple_of:"1024"`
+		}
+
+		loader := NewEnvLoader()
+		cfg := &StepConfig{}
+		assert.NoError(t, loader.LoadFromMap(map[string]string{"STEP_BUFFER": "8192"}, cfg))
+		assert.Equal(t, 8192, cfg.Buffer)
+
+		err := loader.LoadFromMap(map[string]string{"STEP_BUFFER": "1500"}, cfg)
+		assert.ErrorContains(t, err, ErrNotMultiple)
+
+		err = loader.LoadFromMap(map[string]string{"STEP_BUFFER": "131072"}, cfg)
+		assert.ErrorContains(t, err, ErrOutOfRange)
+	})
+}
+
+func TestPatternValidator_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		tag     string
+		wantErr string
+	}{
+		{"match", "my-slug-1", `pattern:"^[a-z0-9-]+$"`, ""},
+		{"non-match", "My Slug", `pattern:"^[a-z0-9-]+$"`, ErrPatternMismatch},
+		{"custom message", "My Slug", `pattern:"^[a-z0-9-]+$" pattern_error:"slug must be lowercase"`, "slug must be lowercase"},
+		{"empty value skipped", "", `pattern:"^[a-z]+$"`, ""},
+		{"no pattern tag", "anything", ``, ""},
+		{"non-string ignored", 42, `pattern:"^[a-z]+$"`, ""},
+		{"invalid regex", "abc", `pattern:"[a-z"`, ErrInvalidPattern},
+	}
+
+	validator := &PatternValidator{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Validate(reflect.ValueOf(tt.value), reflect.StructTag(tt.tag))
+			if tt.wantErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPatternValidator_Cache(t *testing.T) {
+	validator := &PatternValidator{}
+	tag := reflect.StructTag(`pattern:"^[a-z]+$"`)
+
+	assert.NoError(t, validator.Validate(reflect.ValueOf("abc"), tag))
+	assert.Error(t, validator.Validate(reflect.ValueOf("ABC"), tag))
+
+	cached, ok := validator.cache.Load("^[a-z]+$")
+	assert.True(t, ok)
+	assert.IsType(t, &regexp.Regexp{}, cached)
+}
+
+func TestOneOfValidator_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		tag     string
+		wantErr bool
+	}{
+		{"valid value", "staging", `oneof:"dev staging prod"`, false},
+		{"invalid value", "qa", `oneof:"dev staging prod"`, true},
+		{"case sensitive", "PROD", `oneof:"dev staging prod"`, true},
+		{"empty value skipped", "", `oneof:"dev staging prod"`, false},
+		{"no tag", "anything", ``, false},
+		{"valid int", 2, `oneof:"1 2 3"`, false},
+		{"invalid int", 4, `oneof:"1 2 3"`, true},
+		{"valid uint", uint8(3), `oneof:"1 2 3"`, false},
+	}
+
+	validator := &OneOfValidator{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Validate(reflect.ValueOf(tt.value), reflect.StructTag(tt.tag))
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), ErrNotOneOf)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run("error lists allowed values", func(t *testing.T) {
+		err := validator.Validate(reflect.ValueOf("qa"), `oneof:"dev staging prod"`)
+		assert.EqualError(t, err, ErrNotOneOf+`: "qa" not in [dev, staging, prod]`)
+	})
+
+	t.Run("case-insensitive match is normalized", func(t *testing.T) {
+		value := "PROD"
+		err := validator.Validate(reflect.ValueOf(&value).Elem(), `oneof:"dev staging prod" oneof_ci:"true"`)
+		assert.NoError(t, err)
+		assert.Equal(t, "prod", value)
+	})
+
+	t.Run("case-insensitive non-match", func(t *testing.T) {
+		value := "QA"
+		err := validator.Validate(reflect.ValueOf(&value).Elem(), `oneof:"dev staging prod" oneof_ci:"true"`)
+		assert.ErrorContains(t, err, ErrNotOneOf)
+		assert.Equal(t, "QA", value)
+	})
+}
+
+func TestLengthValidator_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		tag     string
+		wantErr string
+	}{
+		{"too short", "short", `minlen:"16" maxlen:"64"`, "less than minimum 16"},
+		{"too long", strings.Repeat("x", 65), `minlen:"16" maxlen:"64"`, "greater than maximum 64"},
+		{"in bounds", strings.Repeat("x", 32), `minlen:"16" maxlen:"64"`, ""},
+		{"exact bounds", strings.Repeat("x", 16), `minlen:"16" maxlen:"16"`, ""},
+		{"slice too short", []string{"a"}, `minlen:"2"`, "length 1 is less than minimum 2"},
+		{"slice too long", []string{"a", "b", "c"}, `maxlen:"2"`, "length 3 is greater than maximum 2"},
+		{"slice in bounds", []string{"a", "b"}, `minlen:"1" maxlen:"2"`, ""},
+		{"no tags", "anything", ``, ""},
+		{"invalid minlen", "abc", `minlen:"two"`, "invalid minlen value"},
+		{"invalid maxlen", "abc", `maxlen:"two"`, "invalid maxlen value"},
+		{"non-length kind ignored", 42, `minlen:"5"`, ""},
+		{"empty element rejected", []string{"a", "", "b"}, `no_empty:"true"`, ErrEmptyElement + " at index 1"},
+		{"empty element allowed without flag", []string{"a", "", "b"}, `minlen:"1"`, ""},
+		{"no empty elements", []string{"a", "b"}, `no_empty:"true" minlen:"1"`, ""},
+		{"no_empty ignores strings", "", `no_empty:"true"`, ""},
+	}
+
+	validator := &LengthValidator{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Validate(reflect.ValueOf(tt.value), reflect.StructTag(tt.tag))
+			if tt.wantErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestURLValidator_Validate(t *testing.T) {
+	mustParse := func(raw string) url.URL {
+		u, err := url.Parse(raw)
+		assert.NoError(t, err)
+		return *u
+	}
+
+	tests := []struct {
+		name    string
+		value   interface{}
+		tag     string
+		wantErr bool
+	}{
+		{"scheme present", mustParse("https://example.com"), `require_scheme:"true"`, false},
+		{"scheme missing", mustParse("example.com/path"), `require_scheme:"true"`, true},
+		{"scheme missing without tag", mustParse("example.com/path"), ``, false},
+		{"empty url skipped", url.URL{}, `require_scheme:"true"`, false},
+		{"pointer scheme missing", func() *url.URL { u := mustParse("example.com"); return &u }(), `require_scheme:"true"`, true},
+		{"non-url ignored", "example.com", `require_scheme:"true"`, false},
+	}
+
+	validator := &URLValidator{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Validate(reflect.ValueOf(tt.value), reflect.StructTag(tt.tag))
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), ErrMissingScheme)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestFormatValidator_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		tag     string
+		wantErr string
+	}{
+		{"valid email", "support@example.com", `format:"email"`, ""},
+		{"invalid email", "support.example.com", `format:"email"`, ErrFormatMismatch},
+		{"email with display name", "Support <support@example.com>", `format:"email"`, ErrFormatMismatch},
+		{"valid hostname", "db-1.internal.example.com", `format:"hostname"`, ""},
+		{"invalid hostname", "db_1.internal", `format:"hostname"`, ErrFormatMismatch},
+		{"valid uri", "postgres://db:5432/app", `format:"uri"`, ""},
+		{"uri without scheme", "example.com/callback", `format:"uri"`, ErrFormatMismatch},
+		{"host and port is not a uri", "db:5432", `format:"uri"`, ErrFormatMismatch},
+		{"localhost and port is not a uri", "localhost:8080", `format:"uri"`, ErrFormatMismatch},
+		{"opaque uri", "mailto:ops@example.com", `format:"uri"`, ""},
+		{"empty value skipped", "", `format:"email"`, ""},
+		{"json format ignored", `{"a":1}`, `format:"json"`, ""},
+		{"clock format ignored", "01:30:00", `format:"clock"`, ""},
+		{"unknown format", "value", `format:"phone"`, ErrUnknownFormat},
+		{"unknown format with empty value", "", `format:"phone"`, ErrUnknownFormat},
+		{"no tag", "anything", ``, ""},
+	}
+
+	validator := &FormatValidator{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Validate(reflect.ValueOf(tt.value), reflect.StructTag(tt.tag))
+			if tt.wantErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTimeRangeValidator_Validate(t *testing.T) {
+	date := func(s string) time.Time {
+		d, err := time.Parse("2006-01-02", s)
+		assert.NoError(t, err)
+		return d
+	}
+
+	tests := []struct {
+		name    string
+		value   interface{}
+		tag     string
+		wantErr string
+	}{
+		{"after the bound", date("2021-06-01"), `layout:"2006-01-02" after:"2020-01-01"`, ""},
+		{"before the after bound", date("2019-06-01"), `layout:"2006-01-02" after:"2020-01-01"`, "time must be after 2020-01-01, got 2019-06-01"},
+		{"equal to the after bound", date("2020-01-01"), `layout:"2006-01-02" after:"2020-01-01"`, ErrTimeNotAfter},
+		{"before the bound", date("2019-06-01"), `layout:"2006-01-02" before:"2020-01-01"`, ""},
+		{"after the before bound", date("2021-06-01"), `layout:"2006-01-02" before:"2020-01-01"`, "time must be before 2020-01-01, got 2021-06-01"},
+		{"between bounds", date("2021-06-01"), `layout:"2006-01-02" after:"2020-01-01" before:"2022-01-01"`, ""},
+		{"RFC 3339 by default", date("2021-06-01"), `after:"2021-06-01T12:00:00Z"`, ErrTimeNotAfter},
+		{"date-only bound without layout", date("2021-06-01"), `after:"2020-01-01"`, ""},
+		{"date-only bound without layout failing", date("2019-06-01"), `after:"2020-01-01"`, ErrTimeNotAfter},
+		{"invalid bound without layout", date("2021-06-01"), `after:"01/01/2020"`, `invalid after value "01/01/2020"`},
+		{"pointer", func() *time.Time { d := date("2019-06-01"); return &d }(), `layout:"2006-01-02" after:"2020-01-01"`, ErrTimeNotAfter},
+		{"zero time skipped", time.Time{}, `after:"2020-01-01T00:00:00Z"`, ""},
+		{"invalid bound", date("2021-06-01"), `layout:"2006-01-02" after:"01/01/2020"`, `invalid after value "01/01/2020"`},
+		{"no tags", date("2021-06-01"), ``, ""},
+		{"not a time", "2021-06-01", `after:"2020-01-01"`, ""},
+	}
+
+	validator := &TimeRangeValidator{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Validate(reflect.ValueOf(tt.value), reflect.StructTag(tt.tag))
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRequiredIfValidator_ValidateWithParent(t *testing.T) {
+	type TLSConfig struct {
+		TLSCert    string `required_if:"TLSEnabled=true"`
+		TLSEnabled bool
+		Mode       string
+		Bad        string `required_if:"TLSEnabled"`
+		Unknown    string `required_if:"Missing=true"`
+		Hidden     string `required_if:"mode=tls"`
+		mode       string
+	}
+
+	validator := &RequiredIfValidator{}
+	validate := func(cfg TLSConfig, name string) error {
+		v := reflect.ValueOf(cfg)
+		sf, _ := v.Type().FieldByName(name)
+		return validator.ValidateWithParent(v, v.FieldByName(name), sf.Tag)
+	}
+
+	t.Run("condition met and empty", func(t *testing.T) {
+		err := validate(TLSConfig{TLSEnabled: true}, "TLSCert")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "required when TLSEnabled=true")
+	})
+
+	t.Run("condition met and set", func(t *testing.T) {
+		assert.NoError(t, validate(TLSConfig{TLSEnabled: true, TLSCert: "/cert.pem"}, "TLSCert"))
+	})
+
+	t.Run("condition not met", func(t *testing.T) {
+		assert.NoError(t, validate(TLSConfig{TLSEnabled: false}, "TLSCert"))
+	})
+
+	t.Run("no tag", func(t *testing.T) {
+		assert.NoError(t, validate(TLSConfig{}, "Mode"))
+	})
+
+	t.Run("malformed condition", func(t *testing.T) {
+		err := validate(TLSConfig{}, "Bad")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "expected Field=value")
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		err := validate(TLSConfig{}, "Unknown")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown field Missing")
+	})
+
+	t.Run("unexported field", func(t *testing.T) {
+		var err error
+		assert.NotPanics(t, func() { err = validate(TLSConfig{mode: "tls"}, "Hidden") })
+		assert.ErrorContains(t, err, `invalid required_if condition "mode=tls": unexported field mode`)
+	})
+
+	t.Run("field promoted through nil embedded pointer", func(t *testing.T) {
+		type common struct {
+			Host string
+		}
+		type EmbedConfig struct {
+			*common
+			Port string `required_if:"Host=db"`
+		}
+
+		v := reflect.ValueOf(EmbedConfig{})
+		sf, _ := v.Type().FieldByName("Port")
+		assert.NotPanics(t, func() {
+			assert.NoError(t, validator.ValidateWithParent(v, v.FieldByName("Port"), sf.Tag))
+		})
+	})
+}